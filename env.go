@@ -11,6 +11,8 @@ const (
 	ConsistentlyPollingIntervalEnvVarName = "GOMEGA_DEFAULT_CONSISTENTLY_POLLING_INTERVAL"
 	EventuallyTimeoutEnvVarName           = "GOMEGA_DEFAULT_EVENTUALLY_TIMEOUT"
 	EventuallyPollingIntervalEnvVarName   = "GOMEGA_DEFAULT_EVENTUALLY_POLLING_INTERVAL"
+	PollingStrategyEnvVarName             = "GOMEGA_DEFAULT_POLLING_STRATEGY"
+	PollingMaxIntervalEnvVarName          = "GOMEGA_DEFAULT_POLLING_MAX_INTERVAL"
 )
 
 func init() {
@@ -37,4 +39,11 @@ func init() {
 		SetDefaultEventuallyPollingInterval,
 		EventuallyPollingIntervalEnvVarName,
 	)
+
+	defaults.SetPollingStrategyFromEnv(
+		os.Getenv,
+		SetDefaultPollingStrategy,
+		PollingStrategyEnvVarName,
+		PollingMaxIntervalEnvVarName,
+	)
 }
@@ -0,0 +1,200 @@
+package gomega
+
+import (
+	"context"
+	"time"
+
+	"github.com/onsi/gomega/internal/assertion"
+	"github.com/onsi/gomega/internal/testingtsupport"
+	"github.com/onsi/gomega/types"
+)
+
+// AssertionError is the error returned by a failed Check or ErrorGomega assertion.  Its message is the failure
+// message a fail handler would have been given; Failure carries the failure's raw values.
+type AssertionError struct {
+	Failure types.Failure
+}
+
+func (e *AssertionError) Error() string {
+	return e.Failure.Message
+}
+
+// ErrorAssertion is returned by Check and ErrorGomega.Expect.  It is an Assertion whose methods return an
+// *AssertionError, rather than calling a fail handler, when the assertion fails.
+type ErrorAssertion = types.ErrorAssertion
+
+// ErrorAsyncAssertion is returned by ErrorGomega.Eventually and ErrorGomega.Consistently.  It is an AsyncAssertion
+// whose Should and ShouldNot return an *AssertionError, rather than calling a fail handler, when the assertion fails.
+type ErrorAsyncAssertion = types.ErrorAsyncAssertion
+
+// Check makes an assertion that returns an error instead of failing a test.  This lets Gomega's matchers be used
+// outside of tests, in smoke-test binaries or readiness probes for example:
+//
+//    if err := Check(resp.StatusCode).To(Equal(http.StatusOK)); err != nil {
+//        log.Fatal(err)
+//    }
+//
+// The error's message is the failure message Expect would have reported.  No fail handler needs to be registered.
+// As with Expect, any extra values must be nil or zero.
+func Check(actual interface{}, extra ...interface{}) ErrorAssertion {
+	return newErrorAssertion(actual, extra)
+}
+
+// ErrorGomega is a Gomega whose assertions return errors instead of calling a fail handler.  See Check.
+type ErrorGomega struct {
+	defaults *asyncDefaults
+}
+
+// NewErrorGomega returns an ErrorGomega.  GomegaOptions configure its Eventually and Consistently defaults, as with
+// NewGomega:
+//
+//    g := NewErrorGomega(WithDefaultEventuallyTimeout(time.Minute))
+//    if err := g.Eventually(server.Ready).Should(BeTrue()); err != nil {
+//        os.Exit(1)
+//    }
+func NewErrorGomega(options ...GomegaOption) *ErrorGomega {
+	defaults := *globalAsyncDefaults
+	for _, option := range options {
+		option(&defaults)
+	}
+	return &ErrorGomega{defaults: &defaults}
+}
+
+// Expect is used to make assertions. See documentation for Check.
+func (g *ErrorGomega) Expect(actual interface{}, extra ...interface{}) ErrorAssertion {
+	return newErrorAssertion(actual, extra)
+}
+
+// Eventually is used to make asynchronous assertions. See documentation for Eventually.
+func (g *ErrorGomega) Eventually(actual interface{}, intervals ...interface{}) ErrorAsyncAssertion {
+	a := &errorAsyncAssertion{}
+	a.assertion = g.defaults.eventually(actual, a.failWrapper(), 1, intervals...)
+	return a
+}
+
+// Consistently is used to make asynchronous assertions. See documentation for Consistently.
+func (g *ErrorGomega) Consistently(actual interface{}, intervals ...interface{}) ErrorAsyncAssertion {
+	a := &errorAsyncAssertion{}
+	a.assertion = g.defaults.consistently(actual, a.failWrapper(), 1, intervals...)
+	return a
+}
+
+// failureRecorder keeps the last failure reported to its fail wrapper
+type failureRecorder struct {
+	failure *types.Failure
+}
+
+func (r *failureRecorder) failWrapper() *types.GomegaFailWrapper {
+	return &types.GomegaFailWrapper{
+		Fail: func(message string, callerSkip ...int) {
+			r.failure = &types.Failure{Message: message}
+		},
+		FailureHandler: func(failure types.Failure, callerSkip ...int) {
+			r.failure = &failure
+		},
+		TWithHelper: testingtsupport.EmptyTWithHelper{},
+	}
+}
+
+func (r *failureRecorder) record(passed bool) error {
+	failure := r.failure
+	r.failure = nil
+	if passed || failure == nil {
+		return nil
+	}
+	return &AssertionError{Failure: *failure}
+}
+
+type errorAssertion struct {
+	failureRecorder
+	assertion types.Assertion
+}
+
+func newErrorAssertion(actual interface{}, extra []interface{}) *errorAssertion {
+	a := &errorAssertion{}
+	a.assertion = assertion.New(actual, a.failWrapper(), 1, extra...)
+	return a
+}
+
+func (a *errorAssertion) Should(matcher types.GomegaMatcher, optionalDescription ...interface{}) error {
+	return a.record(a.assertion.Should(matcher, optionalDescription...))
+}
+
+func (a *errorAssertion) ShouldNot(matcher types.GomegaMatcher, optionalDescription ...interface{}) error {
+	return a.record(a.assertion.ShouldNot(matcher, optionalDescription...))
+}
+
+func (a *errorAssertion) To(matcher types.GomegaMatcher, optionalDescription ...interface{}) error {
+	return a.record(a.assertion.To(matcher, optionalDescription...))
+}
+
+func (a *errorAssertion) ToNot(matcher types.GomegaMatcher, optionalDescription ...interface{}) error {
+	return a.record(a.assertion.ToNot(matcher, optionalDescription...))
+}
+
+func (a *errorAssertion) NotTo(matcher types.GomegaMatcher, optionalDescription ...interface{}) error {
+	return a.record(a.assertion.NotTo(matcher, optionalDescription...))
+}
+
+type errorAsyncAssertion struct {
+	failureRecorder
+	assertion types.AsyncAssertion
+}
+
+func (a *errorAsyncAssertion) Should(matcher types.GomegaMatcher, optionalDescription ...interface{}) error {
+	return a.record(a.assertion.Should(matcher, optionalDescription...))
+}
+
+func (a *errorAsyncAssertion) ShouldNot(matcher types.GomegaMatcher, optionalDescription ...interface{}) error {
+	return a.record(a.assertion.ShouldNot(matcher, optionalDescription...))
+}
+
+func (a *errorAsyncAssertion) WithContext(ctx context.Context) ErrorAsyncAssertion {
+	a.assertion = a.assertion.WithContext(ctx)
+	return a
+}
+
+func (a *errorAsyncAssertion) WithTimeout(interval time.Duration) ErrorAsyncAssertion {
+	a.assertion = a.assertion.WithTimeout(interval)
+	return a
+}
+
+func (a *errorAsyncAssertion) WithPolling(interval time.Duration) ErrorAsyncAssertion {
+	a.assertion = a.assertion.WithPolling(interval)
+	return a
+}
+
+func (a *errorAsyncAssertion) Within(timeout time.Duration) ErrorAsyncAssertion {
+	a.assertion = a.assertion.Within(timeout)
+	return a
+}
+
+func (a *errorAsyncAssertion) ProbeEvery(interval time.Duration) ErrorAsyncAssertion {
+	a.assertion = a.assertion.ProbeEvery(interval)
+	return a
+}
+
+func (a *errorAsyncAssertion) WithTimeline(enabled bool) ErrorAsyncAssertion {
+	a.assertion = a.assertion.WithTimeline(enabled)
+	return a
+}
+
+func (a *errorAsyncAssertion) MustPassRepeatedly(count int) ErrorAsyncAssertion {
+	a.assertion = a.assertion.MustPassRepeatedly(count)
+	return a
+}
+
+func (a *errorAsyncAssertion) WithPollingStrategy(strategy PollingStrategy) ErrorAsyncAssertion {
+	a.assertion = a.assertion.WithPollingStrategy(strategy)
+	return a
+}
+
+func (a *errorAsyncAssertion) WithClock(clock Clock) ErrorAsyncAssertion {
+	a.assertion = a.assertion.WithClock(clock)
+	return a
+}
+
+func (a *errorAsyncAssertion) WithFailOnPanic(enabled bool) ErrorAsyncAssertion {
+	a.assertion = a.assertion.WithFailOnPanic(enabled)
+	return a
+}
@@ -0,0 +1,276 @@
+package format
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// UseStructuralDiff (default true) makes MessageWithStructuralDiff, and so Equal and BeEquivalentTo, list only the paths
+// at which two composite values differ instead of printing both values in full.
+var UseStructuralDiff = true
+
+/*
+Difference is a path at which two values differ, along with the formatted values found there.  It prints as:
+
+	.Spec.Containers[2].Image: "a" != "b"
+
+Values missing from one side, like a map key or a trailing slice element, are formatted as <missing>.
+*/
+type Difference struct {
+	Path     string
+	Actual   string
+	Expected string
+}
+
+func (d Difference) String() string {
+	if d.Path == "" {
+		return fmt.Sprintf("%s != %s", d.Actual, d.Expected)
+	}
+	return fmt.Sprintf("%s: %s != %s", d.Path, d.Actual, d.Expected)
+}
+
+const missing = "<missing>"
+
+/*
+Diff walks actual and expected side by side and returns every path at which they differ, in the order it found them.
+Struct fields are visited in declaration order and map keys in the order format.Object prints them in.
+
+Diff only compares composite values: it returns nil unless actual and expected have the same type and are structs,
+maps, slices, arrays or pointers to them.  It also returns nil if they are deeply equal.
+*/
+func Diff(actual interface{}, expected interface{}) []Difference {
+	if !isDiffable(actual, expected) {
+		return nil
+	}
+	d := &differ{visited: map[visit]bool{}}
+	d.diff("", reflect.ValueOf(actual), reflect.ValueOf(expected))
+	return d.differences
+}
+
+/*
+MessageWithStructuralDiff generates a failure message for actual and expected values that were expected to be equal.
+When Diff can compare them, only their types and the paths at which they differ are printed:
+
+	Expected
+	    <main.Pod>
+	to equal
+	    <main.Pod>
+	but they differ at (actual != expected):
+	    .Spec.Containers[2].Image: "a" != "b"
+
+Otherwise, or if the values differ as a whole (one of them is nil, say), or if UseStructuralDiff is false, the message
+is generated by Message.
+*/
+func MessageWithStructuralDiff(actual interface{}, message string, expected interface{}) string {
+	var differences []Difference
+	if UseStructuralDiff {
+		differences = Diff(actual, expected)
+	}
+	return MessageWithDifferences(actual, message, expected, differences)
+}
+
+/*
+MessageWithDifferences is MessageWithStructuralDiff for differences the caller has already found, by comparing actual
+converted to expected's type for example.  The message still names actual's own type.
+*/
+func MessageWithDifferences(actual interface{}, message string, expected interface{}, differences []Difference) string {
+	if !UseStructuralDiff || len(differences) == 0 || differences[0].Path == "" {
+		return Message(actual, message, expected)
+	}
+
+	lines := make([]string, len(differences))
+	for i, difference := range differences {
+		lines[i] = Indent + difference.String()
+	}
+	return fmt.Sprintf("Expected\n%s<%s>\n%s\n%s<%s>\nbut they differ at (actual != expected):\n%s",
+		Indent, formatType(reflect.ValueOf(actual)), message, Indent, formatType(reflect.ValueOf(expected)), strings.Join(lines, "\n"))
+}
+
+func isDiffable(actual interface{}, expected interface{}) bool {
+	if actual == nil || expected == nil || reflect.TypeOf(actual) != reflect.TypeOf(expected) {
+		return false
+	}
+	t := reflect.TypeOf(actual)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Array:
+		return true
+	case reflect.Slice:
+		return t.Elem().Kind() != reflect.Uint8
+	}
+	return false
+}
+
+// visit is a pair of pointers that have been compared already, so that Diff terminates on cyclic values
+type visit struct {
+	actual   uintptr
+	expected uintptr
+	typ      reflect.Type
+}
+
+type differ struct {
+	visited     map[visit]bool
+	differences []Difference
+}
+
+func (d *differ) record(path string, actual string, expected string) {
+	d.differences = append(d.differences, Difference{Path: path, Actual: actual, Expected: expected})
+}
+
+func (d *differ) diff(path string, actual reflect.Value, expected reflect.Value) {
+	if !actual.IsValid() || !expected.IsValid() {
+		if actual.IsValid() != expected.IsValid() {
+			d.record(path, diffValue(actual, true), diffValue(expected, true))
+		}
+		return
+	}
+	if actual.Type() != expected.Type() {
+		d.record(path, diffValue(actual, true), diffValue(expected, true))
+		return
+	}
+
+	if hasStdlibRepresentation(actual) && hasStdlibRepresentation(expected) {
+		if !reflect.DeepEqual(actual.Interface(), expected.Interface()) {
+			d.record(path, diffValue(actual, false), diffValue(expected, false))
+		}
+		return
+	}
+
+	switch actual.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if actual.IsNil() != expected.IsNil() {
+			d.record(path, diffValue(actual, false), diffValue(expected, false))
+			return
+		}
+		if actual.IsNil() || actual.Pointer() == expected.Pointer() && (actual.Kind() != reflect.Slice || actual.Len() == expected.Len()) {
+			return
+		}
+		if actual.Kind() != reflect.Slice {
+			v := visit{actual.Pointer(), expected.Pointer(), actual.Type()}
+			if d.visited[v] {
+				return
+			}
+			d.visited[v] = true
+		}
+	}
+
+	switch actual.Kind() {
+	case reflect.Ptr:
+		d.diff(path, actual.Elem(), expected.Elem())
+	case reflect.Interface:
+		if actual.IsNil() || expected.IsNil() {
+			if actual.IsNil() != expected.IsNil() {
+				d.record(path, diffValue(actual, false), diffValue(expected, false))
+			}
+			return
+		}
+		d.diff(path, actual.Elem(), expected.Elem())
+	case reflect.Struct:
+		if actual.Type() == timeType {
+			if !actual.CanInterface() || !reflect.DeepEqual(actual.Interface(), expected.Interface()) {
+				d.record(path, diffValue(actual, false), diffValue(expected, false))
+			}
+			return
+		}
+		for i := 0; i < actual.NumField(); i++ {
+			d.diff(path+"."+actual.Type().Field(i).Name, actual.Field(i), expected.Field(i))
+		}
+	case reflect.Slice, reflect.Array:
+		if actual.Type().Elem().Kind() == reflect.Uint8 {
+			if !bytes.Equal(byteSlice(actual), byteSlice(expected)) {
+				d.record(path, diffValue(actual, false), diffValue(expected, false))
+			}
+			return
+		}
+		for i := 0; i < actual.Len() || i < expected.Len(); i++ {
+			elementPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= expected.Len():
+				d.record(elementPath, diffValue(actual.Index(i), false), missing)
+			case i >= actual.Len():
+				d.record(elementPath, missing, diffValue(expected.Index(i), false))
+			default:
+				d.diff(elementPath, actual.Index(i), expected.Index(i))
+			}
+		}
+	case reflect.Map:
+		for _, key := range sortedKeys(actual, expected) {
+			keyPath := fmt.Sprintf("%s[%s]", path, formatValue(key, 2, nil))
+			actualValue, expectedValue := actual.MapIndex(key), expected.MapIndex(key)
+			switch {
+			case !expectedValue.IsValid():
+				d.record(keyPath, diffValue(actualValue, false), missing)
+			case !actualValue.IsValid():
+				d.record(keyPath, missing, diffValue(expectedValue, false))
+			default:
+				d.diff(keyPath, actualValue, expectedValue)
+			}
+		}
+	default:
+		if !scalarsEqual(actual, expected) {
+			d.record(path, diffValue(actual, false), diffValue(expected, false))
+		}
+	}
+}
+
+// scalarsEqual compares values that are neither composite nor pointers the way reflect.DeepEqual does, without
+// requiring them to be exported
+func scalarsEqual(actual reflect.Value, expected reflect.Value) bool {
+	switch actual.Kind() {
+	case reflect.Bool:
+		return actual.Bool() == expected.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return actual.Int() == expected.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return actual.Uint() == expected.Uint()
+	case reflect.Float32, reflect.Float64:
+		return actual.Float() == expected.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return actual.Complex() == expected.Complex()
+	case reflect.String:
+		return actual.String() == expected.String()
+	case reflect.Func:
+		return actual.IsNil() && expected.IsNil()
+	case reflect.Chan, reflect.UnsafePointer:
+		return actual.Pointer() == expected.Pointer()
+	}
+	return false
+}
+
+func byteSlice(v reflect.Value) []byte {
+	b := make([]byte, v.Len())
+	for i := range b {
+		b[i] = byte(v.Index(i).Uint())
+	}
+	return b
+}
+
+func sortedKeys(actual reflect.Value, expected reflect.Value) []reflect.Value {
+	keys := actual.MapKeys()
+	for _, key := range expected.MapKeys() {
+		if !actual.MapIndex(key).IsValid() {
+			keys = append(keys, key)
+		}
+	}
+	sortMapEntries(keys, nil, 1, nil)
+	return keys
+}
+
+// diffValue formats a value found at a path, with its type if withType is set.  Interfaces are unwrapped, since the
+// type of the value they hold is what was compared.
+func diffValue(v reflect.Value, withType bool) string {
+	for v.Kind() == reflect.Interface && !v.IsNil() {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return "nil"
+	}
+	if withType {
+		return fmt.Sprintf("<%s>: %s", v.Type(), formatValue(v, 2, nil))
+	}
+	return formatValue(v, 2, nil)
+}
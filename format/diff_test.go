@@ -0,0 +1,124 @@
+package format_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/format"
+)
+
+type diffNode struct {
+	Name     string
+	Children []*diffNode
+	Labels   map[string]string
+	Value    interface{}
+	Next     *diffNode
+	hidden   int
+}
+
+var _ = Describe("Diff", func() {
+	It("should return nil for equal values", func() {
+		Expect(Diff(diffNode{Name: "a", Labels: map[string]string{"x": "y"}}, diffNode{Name: "a", Labels: map[string]string{"x": "y"}})).To(BeEmpty())
+	})
+
+	It("should return nil for values it cannot compare path by path", func() {
+		Expect(Diff(1, 2)).To(BeNil())
+		Expect(Diff("a", "b")).To(BeNil())
+		Expect(Diff([]byte("a"), []byte("b"))).To(BeNil())
+		Expect(Diff([]int{1}, []int64{2})).To(BeNil())
+		Expect(Diff(nil, []int{1})).To(BeNil())
+	})
+
+	It("should walk structs, slices, maps, pointers and interfaces", func() {
+		actual := diffNode{
+			Name:     "root",
+			Children: []*diffNode{{Name: "a"}, {Name: "b"}},
+			Labels:   map[string]string{"app": "web", "tier": "front"},
+			Value:    3,
+			hidden:   1,
+		}
+		expected := diffNode{
+			Name:     "root",
+			Children: []*diffNode{{Name: "a"}, {Name: "c"}, {Name: "d"}},
+			Labels:   map[string]string{"app": "db", "zone": "eu"},
+			Value:    "3",
+			hidden:   2,
+		}
+		Expect(Diff(actual, expected)).To(Equal([]Difference{
+			{Path: ".Children[1].Name", Actual: `"b"`, Expected: `"c"`},
+			{Path: ".Children[2]", Actual: "<missing>", Expected: `{Name: "d", Children: nil, Labels: nil, Value: nil, Next: nil, hidden: 0}`},
+			{Path: `.Labels["app"]`, Actual: `"web"`, Expected: `"db"`},
+			{Path: `.Labels["tier"]`, Actual: `"front"`, Expected: "<missing>"},
+			{Path: `.Labels["zone"]`, Actual: "<missing>", Expected: `"eu"`},
+			{Path: ".Value", Actual: "<int>: 3", Expected: `<string>: "3"`},
+			{Path: ".hidden", Actual: "1", Expected: "2"},
+		}))
+	})
+
+	It("should report nil against non-nil", func() {
+		Expect(Diff(diffNode{Labels: map[string]string{}}, diffNode{})).To(Equal([]Difference{
+			{Path: ".Labels", Actual: "{}", Expected: "nil"},
+		}))
+		Expect(Diff(&diffNode{}, (*diffNode)(nil))).To(Equal([]Difference{
+			{Path: "", Actual: "{Name: \"\", Children: nil, Labels: nil, Value: nil, Next: nil, hidden: 0}", Expected: "nil"},
+		}))
+	})
+
+	It("should compare times as a whole", func() {
+		t := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+		Expect(Diff([]time.Time{t}, []time.Time{t.Add(time.Second)})).To(Equal([]Difference{
+			{Path: "[0]", Actual: "2020-01-01T00:00:00Z", Expected: "2020-01-01T00:00:01Z"},
+		}))
+	})
+
+	It("should terminate on cyclic values", func() {
+		actual := &diffNode{Name: "a"}
+		actual.Next = actual
+		expected := &diffNode{Name: "b"}
+		expected.Next = expected
+		Expect(Diff(actual, expected)).To(Equal([]Difference{
+			{Path: ".Name", Actual: `"a"`, Expected: `"b"`},
+		}))
+	})
+
+	It("should print differences as path: actual != expected", func() {
+		Expect(Difference{Path: ".A[2]", Actual: `"a"`, Expected: `"b"`}.String()).To(Equal(`.A[2]: "a" != "b"`))
+		Expect(Difference{Actual: "nil", Expected: "{}"}.String()).To(Equal("nil != {}"))
+	})
+})
+
+var _ = Describe("MessageWithStructuralDiff", func() {
+	It("should print only the types and the differing paths", func() {
+		message := MessageWithStructuralDiff(map[string]int{"a": 1, "b": 2}, "to equal", map[string]int{"a": 1, "b": 3})
+		Expect(message).To(Equal("Expected\n    <map[string]int | len:2>\nto equal\n    <map[string]int | len:2>\nbut they differ at (actual != expected):\n    [\"b\"]: 2 != 3"))
+	})
+
+	It("should fall back to Message when the values cannot be diffed or differ as a whole", func() {
+		Expect(MessageWithStructuralDiff(1, "to equal", 2)).To(Equal(Message(1, "to equal", 2)))
+		Expect(MessageWithStructuralDiff([]int(nil), "to equal", []int{})).To(Equal(Message([]int(nil), "to equal", []int{})))
+	})
+
+	It("should fall back to Message when UseStructuralDiff is false", func() {
+		UseStructuralDiff = false
+		defer func() { UseStructuralDiff = true }()
+		Expect(MessageWithStructuralDiff([]int{1}, "to equal", []int{2})).To(Equal(Message([]int{1}, "to equal", []int{2})))
+	})
+})
+
+var _ = Describe("MessageWithDifferences", func() {
+	It("should print the given differences under the values' own types", func() {
+		differences := []Difference{{Path: "[0]", Actual: "1", Expected: "2"}}
+		message := MessageWithDifferences([]int64{1}, "to be equivalent to", []int{2}, differences)
+		Expect(message).To(Equal("Expected\n    <[]int64 | len:1, cap:1>\nto be equivalent to\n    <[]int | len:1, cap:1>\nbut they differ at (actual != expected):\n    [0]: 1 != 2"))
+	})
+
+	It("should fall back to Message without differences or when UseStructuralDiff is false", func() {
+		Expect(MessageWithDifferences([]int{1}, "to equal", []int{2}, nil)).To(Equal(Message([]int{1}, "to equal", []int{2})))
+
+		UseStructuralDiff = false
+		defer func() { UseStructuralDiff = true }()
+		differences := []Difference{{Path: "[0]", Actual: "1", Expected: "2"}}
+		Expect(MessageWithDifferences([]int{1}, "to equal", []int{2}, differences)).To(Equal(Message([]int{1}, "to equal", []int{2})))
+	})
+})
@@ -9,7 +9,9 @@ package format
 import (
 	"context"
 	"fmt"
+	"math"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -65,6 +67,70 @@ type GomegaStringer interface {
 	GomegaString() string
 }
 
+/*
+CustomFormatter can be registered with RegisterCustomFormatter to format values of types that can't implement
+GomegaStringer, like third-party types:
+
+	format.RegisterCustomFormatter(func(value interface{}) (string, bool) {
+		if n, ok := value.(*big.Int); ok {
+			return n.String(), true
+		}
+		return "", false
+	})
+
+A CustomFormatter returns the representation of value and true if it handles value, and false otherwise.  Like
+GomegaString, the representation is not truncated.
+*/
+type CustomFormatter func(value interface{}) (string, bool)
+
+// CustomFormatterKey is returned by RegisterCustomFormatter and unregisters the formatter when passed to
+// UnregisterCustomFormatter.
+type CustomFormatterKey uint
+
+type registeredCustomFormatter struct {
+	key       CustomFormatterKey
+	formatter CustomFormatter
+}
+
+var customFormatters = []registeredCustomFormatter{}
+var lastCustomFormatterKey CustomFormatterKey
+
+/*
+RegisterCustomFormatter registers a CustomFormatter.  It is consulted for every value format prints, at every level of
+nesting, before GomegaStringer and UseStringerRepresentation.  Formatters are consulted in the order they were
+registered and the first one to handle a value wins.
+
+Values held in unexported struct fields can't be handed to a CustomFormatter and are formatted as usual.
+
+RegisterCustomFormatter and UnregisterCustomFormatter must not be called while format is printing values in other
+goroutines.
+*/
+func RegisterCustomFormatter(customFormatter CustomFormatter) CustomFormatterKey {
+	lastCustomFormatterKey++
+	customFormatters = append(customFormatters, registeredCustomFormatter{key: lastCustomFormatterKey, formatter: customFormatter})
+	return lastCustomFormatterKey
+}
+
+// UnregisterCustomFormatter unregisters the CustomFormatter registered with key.  Unregistering a formatter twice does
+// nothing.
+func UnregisterCustomFormatter(key CustomFormatterKey) {
+	for i, registered := range customFormatters {
+		if registered.key == key {
+			customFormatters = append(customFormatters[:i:i], customFormatters[i+1:]...)
+			return
+		}
+	}
+}
+
+func applyCustomFormatters(obj interface{}) (string, bool) {
+	for _, registered := range customFormatters {
+		if representation, ok := registered.formatter(obj); ok {
+			return representation, true
+		}
+	}
+	return "", false
+}
+
 /*
 Generates a formatted matcher success/failure message of the form:
 
@@ -201,7 +267,9 @@ func truncateLongStrings(s string) string {
 /*
 Pretty prints the passed in object at the passed in indentation level.
 
-Object recurses into deeply nested objects emitting pretty-printed representations of their components.
+Object recurses into deeply nested objects emitting pretty-printed representations of their components.  Map entries
+are printed in a deterministic order, sorted by key.  Pointers and maps that lead back to a value being printed are
+printed as a back-reference, like <cycle to *main.Node@0xc000010030>, instead of being followed again.
 
 Modify format.MaxDepth to control how deep the recursion is allowed to go
 Set format.UseStringerRepresentation to true to return object.GoString() or object.String() when available instead of
@@ -212,7 +280,7 @@ Set PrintContextObjects to true to print the content of objects implementing con
 func Object(object interface{}, indentation uint) string {
 	indent := strings.Repeat(Indent, int(indentation))
 	value := reflect.ValueOf(object)
-	return fmt.Sprintf("%s<%s>: %s", indent, formatType(value), formatValue(value, indentation))
+	return fmt.Sprintf("%s<%s>: %s", indent, formatType(value), formatValue(value, indentation, nil))
 }
 
 /*
@@ -249,7 +317,7 @@ func formatType(v reflect.Value) string {
 	}
 }
 
-func formatValue(value reflect.Value, indentation uint) string {
+func formatValue(value reflect.Value, indentation uint, ancestors *ancestry) string {
 	if indentation > MaxDepth {
 		return "..."
 	}
@@ -261,12 +329,23 @@ func formatValue(value reflect.Value, indentation uint) string {
 	if value.CanInterface() {
 		obj := value.Interface()
 
+		// do not truncate a user-defined representation
+		if representation, ok := applyCustomFormatters(obj); ok {
+			return representation
+		}
+
 		// GomegaStringer will take precedence to other representations and disregards UseStringerRepresentation
 		if x, ok := obj.(GomegaStringer); ok {
 			// do not truncate a user-defined GoMegaString() value
 			return x.GomegaString()
 		}
 
+		if UseStdlibRepresentation {
+			if representation, ok := formatStdlibValue(obj, indentation); ok {
+				return truncateLongStrings(representation)
+			}
+		}
+
 		if UseStringerRepresentation {
 			switch x := obj.(type) {
 			case fmt.GoStringer:
@@ -301,23 +380,29 @@ func formatValue(value reflect.Value, indentation uint) string {
 	case reflect.Func:
 		return fmt.Sprintf("0x%x", value.Pointer())
 	case reflect.Ptr:
-		return formatValue(value.Elem(), indentation)
+		if ancestors.contains(value) {
+			return formatCycle(value)
+		}
+		return formatValue(value.Elem(), indentation, ancestors.with(value))
 	case reflect.Slice:
-		return truncateLongStrings(formatSlice(value, indentation))
+		return truncateLongStrings(formatSlice(value, indentation, ancestors))
 	case reflect.String:
 		return truncateLongStrings(formatString(value.String(), indentation))
 	case reflect.Array:
-		return truncateLongStrings(formatSlice(value, indentation))
+		return truncateLongStrings(formatSlice(value, indentation, ancestors))
 	case reflect.Map:
-		return truncateLongStrings(formatMap(value, indentation))
+		if ancestors.contains(value) {
+			return formatCycle(value)
+		}
+		return truncateLongStrings(formatMap(value, indentation, ancestors.with(value)))
 	case reflect.Struct:
 		if value.Type() == timeType && value.CanInterface() {
 			t, _ := value.Interface().(time.Time)
 			return t.Format(time.RFC3339Nano)
 		}
-		return truncateLongStrings(formatStruct(value, indentation))
+		return truncateLongStrings(formatStruct(value, indentation, ancestors))
 	case reflect.Interface:
-		return formatInterface(value, indentation)
+		return formatInterface(value, indentation, ancestors)
 	default:
 		if value.CanInterface() {
 			return truncateLongStrings(fmt.Sprintf("%#v", value.Interface()))
@@ -348,7 +433,7 @@ func formatString(object interface{}, indentation uint) string {
 	}
 }
 
-func formatSlice(v reflect.Value, indentation uint) string {
+func formatSlice(v reflect.Value, indentation uint, ancestors *ancestry) string {
 	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 && isPrintableString(string(v.Bytes())) {
 		return formatString(v.Bytes(), indentation)
 	}
@@ -357,7 +442,7 @@ func formatSlice(v reflect.Value, indentation uint) string {
 	result := make([]string, l)
 	longest := 0
 	for i := 0; i < l; i++ {
-		result[i] = formatValue(v.Index(i), indentation+1)
+		result[i] = formatValue(v.Index(i), indentation+1, ancestors)
 		if len(result[i]) > longest {
 			longest = len(result[i])
 		}
@@ -370,14 +455,21 @@ func formatSlice(v reflect.Value, indentation uint) string {
 	return fmt.Sprintf("[%s]", strings.Join(result, ", "))
 }
 
-func formatMap(v reflect.Value, indentation uint) string {
+func formatMap(v reflect.Value, indentation uint, ancestors *ancestry) string {
 	l := v.Len()
 	result := make([]string, l)
 
+	keys, values := make([]reflect.Value, 0, l), make([]reflect.Value, 0, l)
+	for iter := v.MapRange(); iter.Next(); {
+		keys = append(keys, iter.Key())
+		values = append(values, iter.Value())
+	}
+	sortMapEntries(keys, values, indentation, ancestors)
+
 	longest := 0
-	for i, key := range v.MapKeys() {
-		value := v.MapIndex(key)
-		result[i] = fmt.Sprintf("%s: %s", formatValue(key, indentation+1), formatValue(value, indentation+1))
+	for i, key := range keys {
+		value := values[i]
+		result[i] = fmt.Sprintf("%s: %s", formatValue(key, indentation+1, ancestors), formatValue(value, indentation+1, ancestors))
 		if len(result[i]) > longest {
 			longest = len(result[i])
 		}
@@ -390,7 +482,86 @@ func formatMap(v reflect.Value, indentation uint) string {
 	return fmt.Sprintf("{%s}", strings.Join(result, ", "))
 }
 
-func formatStruct(v reflect.Value, indentation uint) string {
+/*
+sortMapEntries sorts map keys, and the values at the same positions, so that maps always print the same way.  Keys of
+the same ordered kind (integers, floats, strings and bools) are sorted naturally; other keys are sorted by their
+representation as formatted for a map at the given indentation.  Keys held in interfaces are grouped by their dynamic
+type first.  values may be nil.
+*/
+func sortMapEntries(keys []reflect.Value, values []reflect.Value, indentation uint, ancestors *ancestry) {
+	sortKeys := make([]mapSortKey, len(keys))
+	for i, key := range keys {
+		sortKeys[i] = newMapSortKey(key, indentation+1, ancestors)
+	}
+	sort.Stable(mapEntriesByKey{keys, values, sortKeys})
+}
+
+type mapSortKey struct {
+	value     reflect.Value
+	typeName  string
+	formatted string
+}
+
+func newMapSortKey(key reflect.Value, indentation uint, ancestors *ancestry) mapSortKey {
+	for key.Kind() == reflect.Interface && !key.IsNil() {
+		key = key.Elem()
+	}
+	sortKey := mapSortKey{value: key}
+	if !key.IsValid() {
+		return sortKey
+	}
+	sortKey.typeName = key.Type().String()
+	switch key.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String, reflect.Bool:
+	default:
+		sortKey.formatted = formatValue(key, indentation, ancestors)
+	}
+	return sortKey
+}
+
+func (k mapSortKey) less(other mapSortKey) bool {
+	if k.typeName != other.typeName {
+		return k.typeName < other.typeName
+	}
+	if !k.value.IsValid() {
+		return false
+	}
+	switch k.value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return k.value.Int() < other.value.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return k.value.Uint() < other.value.Uint()
+	case reflect.Float32, reflect.Float64:
+		a, b := k.value.Float(), other.value.Float()
+		// NaNs sort first so that the order is total
+		return a < b || math.IsNaN(a) && !math.IsNaN(b)
+	case reflect.String:
+		return k.value.String() < other.value.String()
+	case reflect.Bool:
+		return !k.value.Bool() && other.value.Bool()
+	}
+	return k.formatted < other.formatted
+}
+
+type mapEntriesByKey struct {
+	keys     []reflect.Value
+	values   []reflect.Value
+	sortKeys []mapSortKey
+}
+
+func (m mapEntriesByKey) Len() int           { return len(m.keys) }
+func (m mapEntriesByKey) Less(i, j int) bool { return m.sortKeys[i].less(m.sortKeys[j]) }
+func (m mapEntriesByKey) Swap(i, j int) {
+	m.keys[i], m.keys[j] = m.keys[j], m.keys[i]
+	m.sortKeys[i], m.sortKeys[j] = m.sortKeys[j], m.sortKeys[i]
+	if m.values != nil {
+		m.values[i], m.values[j] = m.values[j], m.values[i]
+	}
+}
+
+func formatStruct(v reflect.Value, indentation uint, ancestors *ancestry) string {
 	t := v.Type()
 
 	l := v.NumField()
@@ -399,7 +570,7 @@ func formatStruct(v reflect.Value, indentation uint) string {
 	for i := 0; i < l; i++ {
 		structField := t.Field(i)
 		fieldEntry := v.Field(i)
-		representation := fmt.Sprintf("%s: %s", structField.Name, formatValue(fieldEntry, indentation+1))
+		representation := fmt.Sprintf("%s: %s", structField.Name, formatValue(fieldEntry, indentation+1, ancestors))
 		result = append(result, representation)
 		if len(representation) > longest {
 			longest = len(representation)
@@ -412,8 +583,40 @@ func formatStruct(v reflect.Value, indentation uint) string {
 	return fmt.Sprintf("{%s}", strings.Join(result, ", "))
 }
 
-func formatInterface(v reflect.Value, indentation uint) string {
-	return fmt.Sprintf("<%s>%s", formatType(v.Elem()), formatValue(v.Elem(), indentation))
+func formatInterface(v reflect.Value, indentation uint, ancestors *ancestry) string {
+	return fmt.Sprintf("<%s>%s", formatType(v.Elem()), formatValue(v.Elem(), indentation, ancestors))
+}
+
+/*
+ancestry is the chain of pointers and maps formatValue followed to reach the value it is formatting.  A pointer or map
+that is its own ancestor is part of a cycle and is printed as a back-reference instead of being followed again:
+
+	<cycle to *main.Node@0xc000010030>
+
+A pointer is identified by its address and its type, as a pointer to a struct and a pointer to the struct's first
+field share an address.
+*/
+type ancestry struct {
+	pointer uintptr
+	typ     reflect.Type
+	parent  *ancestry
+}
+
+func (a *ancestry) contains(v reflect.Value) bool {
+	for ; a != nil; a = a.parent {
+		if a.pointer == v.Pointer() && a.typ == v.Type() {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *ancestry) with(v reflect.Value) *ancestry {
+	return &ancestry{pointer: v.Pointer(), typ: v.Type(), parent: a}
+}
+
+func formatCycle(v reflect.Value) string {
+	return fmt.Sprintf("<cycle to %s@0x%x>", v.Type(), v.Pointer())
 }
 
 func isNilValue(a reflect.Value) bool {
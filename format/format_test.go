@@ -3,6 +3,7 @@ package format_test
 import (
 	"context"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -464,6 +465,36 @@ var _ = Describe("Format", func() {
 					Expect(Object(m, 1)).Should(matchRegexp(`map\[string\]\[\]uint8 \| len:3`, expected))
 				})
 			})
+
+			Describe("ordering keys", func() {
+				It("should sort ordered keys naturally", func() {
+					Expect(Object(map[int]bool{10: true, -2: false, 3: true}, 1)).Should(match("map[int]bool | len:3", "{-2: false, 3: true, 10: true}"))
+					Expect(Object(map[float64]int{2.5: 1, math.NaN(): 2, -1: 3}, 1)).Should(match("map[float64]int | len:3", "{NaN: 2, -1: 3, 2.5: 1}"))
+					Expect(Object(map[string]int{"b": 1, "a": 2, "B": 3}, 1)).Should(match("map[string]int | len:3", `{"B": 3, "a": 2, "b": 1}`))
+					Expect(Object(map[bool]int{true: 1, false: 2}, 1)).Should(match("map[bool]int | len:2", "{false: 2, true: 1}"))
+				})
+
+				It("should sort other keys by their formatted representation", func() {
+					m := map[AStruct]int{{"b"}: 1, {"c"}: 2, {"a"}: 3}
+					Expect(Object(m, 1)).Should(match("map[format_test.AStruct]int | len:3", `{{Exported: "a"}: 3, {Exported: "b"}: 1, {Exported: "c"}: 2}`))
+				})
+
+				It("should group interface keys by type", func() {
+					m := map[interface{}]int{"b": 1, 2: 2, "a": 3, 1: 4}
+					Expect(Object(m, 1)).Should(match("map[interface {}]int | len:4", `{<int>1: 4, <int>2: 2, <string>"a": 3, <string>"b": 1}`))
+				})
+
+				It("should print the same map the same way every time", func() {
+					m := map[string]int{}
+					for i := 0; i < 50; i++ {
+						m[fmt.Sprintf("key-%d", i)] = i
+					}
+					first := Object(m, 1)
+					for i := 0; i < 10; i++ {
+						Expect(Object(m, 1)).Should(Equal(first))
+					}
+				})
+			})
 		})
 
 		Describe("formatting structs", func() {
@@ -648,7 +679,7 @@ var _ = Describe("Format", func() {
 			m := map[string]interface{}{}
 			m["integer"] = 2
 			m["map"] = m
-			Expect(Object(m, 1)).Should(ContainSubstring("..."))
+			Expect(Object(m, 1)).Should(ContainSubstring(fmt.Sprintf(`"map": <map[string]interface {} | len:2><cycle to map[string]interface {}@%p>`, m)))
 		})
 
 		It("really should not go crazy...", func() {
@@ -660,7 +691,38 @@ var _ = Describe("Format", func() {
 			complexObject.Value = make(map[interface{}]int)
 
 			complexObject.Value[&complexObject] = 2
-			Expect(Object(complexObject, 1)).Should(ContainSubstring("..."))
+			Expect(Object(complexObject, 1)).Should(ContainSubstring(fmt.Sprintf("Value: <cycle to map[interface {}]int@%p>", complexObject.Value)))
+		})
+
+		It("should print back-references for pointer cycles", func() {
+			type node struct {
+				Name       string
+				Prev, Next *node
+			}
+			first := &node{Name: "first"}
+			second := &node{Name: "second", Prev: first}
+			first.Next = second
+
+			Expect(Object(first, 1)).Should(match(fmt.Sprintf("*format_test.node | %p", first), `{
+        Name: "first",
+        Prev: nil,
+        Next: {
+            Name: "second",
+            Prev: <cycle to *format_test.node@%p>,
+            Next: nil,
+        },
+    }`, first))
+		})
+
+		It("should follow pointers that are shared but not cyclic", func() {
+			type pair struct {
+				Left, Right *AStruct
+			}
+			shared := &AStruct{"shared"}
+			Expect(Object(pair{shared, shared}, 1)).Should(match("format_test.pair", `{
+        Left: {Exported: "shared"},
+        Right: {Exported: "shared"},
+    }`))
 		})
 	})
 
@@ -731,6 +793,78 @@ var _ = Describe("Format", func() {
 			})
 		})
 	})
+
+	Describe("Custom formatters", func() {
+		var keys []CustomFormatterKey
+
+		register := func(formatter CustomFormatter) CustomFormatterKey {
+			key := RegisterCustomFormatter(formatter)
+			keys = append(keys, key)
+			return key
+		}
+
+		formatAStruct := func(value interface{}) (string, bool) {
+			if s, ok := value.(AStruct); ok {
+				return "AStruct(" + s.Exported + ")", true
+			}
+			return "", false
+		}
+
+		BeforeEach(func() {
+			keys = nil
+		})
+
+		AfterEach(func() {
+			for _, key := range keys {
+				UnregisterCustomFormatter(key)
+			}
+		})
+
+		It("should format the values the formatter handles", func() {
+			register(formatAStruct)
+			Expect(Object(AStruct{"a"}, 1)).Should(match("format_test.AStruct", "AStruct(a)"))
+			Expect(Object(SimpleStruct{Name: "b"}, 1)).Should(ContainSubstring(`Name: "b"`))
+		})
+
+		It("should be consulted at every level of nesting", func() {
+			register(formatAStruct)
+			pointer := &AStruct{"p"}
+			Expect(Object([]interface{}{map[string]AStruct{"k": {"v"}}, pointer}, 1)).Should(match("[]interface {} | len:2, cap:2", `[
+        <map[string]format_test.AStruct | len:1>{"k": AStruct(v)},
+        <*format_test.AStruct | %p>AStruct(p),
+    ]`, pointer))
+
+			s := SecretiveStruct{interfaceValue: AStruct{"hidden"}}
+			Expect(Object(s, 1)).Should(ContainSubstring(`interfaceValue: <format_test.AStruct>{Exported: "hidden"}`))
+		})
+
+		It("should take precedence over GomegaStringer and not truncate", func() {
+			register(func(value interface{}) (string, bool) {
+				if _, ok := value.(gomegaStringerLong); ok {
+					return "custom", true
+				}
+				return "", false
+			})
+			Expect(Object(gomegaStringerLong{}, 1)).Should(match("format_test.gomegaStringerLong", "custom"))
+		})
+
+		It("should consult formatters in the order they were registered", func() {
+			register(func(value interface{}) (string, bool) { return "first", true })
+			register(func(value interface{}) (string, bool) { return "second", true })
+			Expect(Object(3, 1)).Should(match("int", "first"))
+		})
+
+		It("should stop consulting a formatter once it is unregistered", func() {
+			key := register(formatAStruct)
+			other := register(func(value interface{}) (string, bool) { return "other", value == 3 })
+			UnregisterCustomFormatter(key)
+			UnregisterCustomFormatter(key)
+			Expect(Object(AStruct{"a"}, 1)).Should(match("format_test.AStruct", `{Exported: "a"}`))
+			Expect(Object(3, 1)).Should(match("int", "other"))
+			UnregisterCustomFormatter(other)
+			Expect(Object(3, 1)).Should(match("int", "3"))
+		})
+	})
 })
 
 var expectedLongStringFailureMessage = strings.TrimSpace(`
@@ -0,0 +1,129 @@
+package format
+
+import (
+	"encoding/json"
+	"math/big"
+	"net"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+)
+
+/*
+UseStdlibRepresentation (default true) prints common standard library types the way they are usually written, rather
+than field by field:
+
+	time.Duration                  1.5s
+	big.Int, big.Float, big.Rat    12345678901234567890, 1.25, 3/4
+	net.IP, net.IPNet              192.168.0.1, 10.0.0.0/8
+	net.HardwareAddr               00:00:5e:00:53:01
+	url.URL                        https://example.com/path?q=1
+	json.RawMessage                {"name": "value"}
+
+Errors are printed as their message, followed by the errors they wrap:
+
+	<*fmt.wrapError | 0xc000010030>: loading config: open config.yml: no such file or directory
+	    wrapping <*fs.PathError | 0xc000010048>: open config.yml: no such file or directory
+	        wrapping <syscall.Errno>: no such file or directory
+
+Set UseStdlibRepresentation = false to print these values like any other.  CustomFormatters and GomegaStringers take
+precedence over these representations.
+*/
+var UseStdlibRepresentation = true
+
+// formatStdlibValue returns the representation of obj if it is one of the standard library types listed in
+// UseStdlibRepresentation's documentation
+func formatStdlibValue(obj interface{}, indentation uint) (string, bool) {
+	switch x := obj.(type) {
+	case time.Duration:
+		return x.String(), true
+	case *big.Int:
+		return x.String(), true
+	case big.Int:
+		return x.String(), true
+	case *big.Float:
+		return x.Text('g', -1), true
+	case big.Float:
+		return x.Text('g', -1), true
+	case *big.Rat:
+		return x.RatString(), true
+	case big.Rat:
+		return x.RatString(), true
+	case net.IP:
+		return x.String(), true
+	case *net.IPNet:
+		return x.String(), true
+	case net.IPNet:
+		return x.String(), true
+	case net.HardwareAddr:
+		return x.String(), true
+	case *url.URL:
+		return formatString(x.String(), indentation), true
+	case url.URL:
+		return formatString(x.String(), indentation), true
+	case json.RawMessage:
+		return formatString(string(x), indentation), true
+	case error:
+		return formatError(x, indentation)
+	}
+	return "", false
+}
+
+// hasStdlibRepresentation is true if formatValue would print v with formatStdlibValue
+func hasStdlibRepresentation(v reflect.Value) bool {
+	if !UseStdlibRepresentation || !v.CanInterface() || isNilValue(v) {
+		return false
+	}
+	_, ok := formatStdlibValue(v.Interface(), MaxDepth)
+	return ok
+}
+
+func formatError(err error, indentation uint) (string, bool) {
+	message, ok := errorMessage(err)
+	if !ok {
+		return "", false
+	}
+	return formatString(message, indentation) + formatWrappedErrors(err, indentation, 1), true
+}
+
+func formatWrappedErrors(err error, indentation uint, depth uint) string {
+	if indentation+depth > MaxDepth {
+		return ""
+	}
+	result := ""
+	for _, wrapped := range wrappedErrors(err) {
+		message, ok := errorMessage(wrapped)
+		if !ok {
+			continue
+		}
+		result += "\n" + strings.Repeat(Indent, int(indentation+depth)) + "wrapping <" + formatType(reflect.ValueOf(wrapped)) + ">: " + formatString(message, indentation)
+		result += formatWrappedErrors(wrapped, indentation, depth+1)
+	}
+	return result
+}
+
+func wrappedErrors(err error) []error {
+	switch x := err.(type) {
+	case interface{ Unwrap() []error }:
+		return x.Unwrap()
+	case interface{ Unwrap() error }:
+		if wrapped := x.Unwrap(); wrapped != nil {
+			return []error{wrapped}
+		}
+	}
+	return nil
+}
+
+// errorMessage calls err.Error(), which may panic for errors that are nil pointers or otherwise broken
+func errorMessage(err error) (message string, ok bool) {
+	if err == nil || isNilValue(reflect.ValueOf(err)) {
+		return "", false
+	}
+	defer func() {
+		if recover() != nil {
+			message, ok = "", false
+		}
+	}()
+	return err.Error(), true
+}
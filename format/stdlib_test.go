@@ -0,0 +1,117 @@
+package format_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/format"
+)
+
+type errorWithFields struct {
+	Code int
+}
+
+func (e errorWithFields) Error() string {
+	return fmt.Sprintf("code %d", e.Code)
+}
+
+type multiError []error
+
+func (m multiError) Error() string {
+	return "several things went wrong"
+}
+
+func (m multiError) Unwrap() []error {
+	return m
+}
+
+var _ = Describe("Formatting standard library types", func() {
+	It("should print durations as a duration", func() {
+		Expect(Object(1500*time.Millisecond, 1)).Should(Equal("    <time.Duration>: 1.5s"))
+	})
+
+	It("should print big numbers as numbers", func() {
+		n, _ := new(big.Int).SetString("12345678901234567890", 10)
+		Expect(Object(n, 1)).Should(HaveSuffix(">: 12345678901234567890"))
+		Expect(Object(*n, 1)).Should(Equal("    <big.Int>: 12345678901234567890"))
+		Expect(Object(big.NewFloat(1.25), 1)).Should(HaveSuffix(">: 1.25"))
+		Expect(Object(big.NewRat(3, 4), 1)).Should(HaveSuffix(">: 3/4"))
+	})
+
+	It("should print network addresses as addresses", func() {
+		Expect(Object(net.ParseIP("192.168.0.1"), 1)).Should(HavePrefix("    <net.IP | len:16, cap:16>: 192.168.0.1"))
+		_, network, _ := net.ParseCIDR("10.0.0.0/8")
+		Expect(Object(network, 1)).Should(HaveSuffix(">: 10.0.0.0/8"))
+		mac, _ := net.ParseMAC("00:00:5e:00:53:01")
+		Expect(Object(mac, 1)).Should(HaveSuffix(">: 00:00:5e:00:53:01"))
+	})
+
+	It("should print URLs as URLs", func() {
+		u, _ := url.Parse("https://example.com/path?q=1")
+		Expect(Object(u, 1)).Should(HaveSuffix(">: https://example.com/path?q=1"))
+		Expect(Object(struct{ U *url.URL }{u}, 1)).Should(ContainSubstring(`U: "https://example.com/path?q=1",`))
+	})
+
+	It("should print raw JSON as a string", func() {
+		raw := json.RawMessage("{\n\"a\": 1}")
+		Expect(Object(raw, 1)).Should(HaveSuffix(" | len:9, cap:9>: {\n    \"a\": 1}"))
+		Expect(Object([]json.RawMessage{raw}, 1)).Should(HaveSuffix(`: ["{\n\"a\": 1}"]`))
+	})
+
+	It("should print errors as their message and the errors they wrap", func() {
+		inner := errorWithFields{Code: 7}
+		err := fmt.Errorf("loading config: %w", inner)
+		Expect(Object(err, 1)).Should(Equal(fmt.Sprintf(`    <*fmt.wrapError | %p>: loading config: code 7
+        wrapping <format_test.errorWithFields>: code 7`, err)))
+		Expect(Object(errors.New("boom"), 1)).Should(HaveSuffix(">: boom"))
+	})
+
+	It("should print every error a multi-error wraps", func() {
+		err := multiError{errors.New("first"), fmt.Errorf("second: %w", errorWithFields{Code: 2})}
+		Expect(Object(err, 1)).Should(HaveSuffix(`>: several things went wrong
+        wrapping <*errors.errorString | ` + fmt.Sprintf("%p", err[0]) + `>: first
+        wrapping <*fmt.wrapError | ` + fmt.Sprintf("%p", err[1]) + `>: second: code 2
+            wrapping <format_test.errorWithFields>: code 2`))
+	})
+
+	It("should quote nested values like other strings", func() {
+		Expect(Object(struct {
+			Timeout time.Duration
+			Err     error
+		}{time.Second, errors.New("boom")}, 1)).Should(HaveSuffix(`: {Timeout: 1s, Err: "boom"}`))
+		Expect(Object([]error{errors.New("boom")}, 1)).Should(HaveSuffix(`: ["boom"]`))
+	})
+
+	It("should treat them as leaves when diffing", func() {
+		type config struct {
+			Limit *big.Int
+			Wait  time.Duration
+		}
+		Expect(Diff(config{big.NewInt(1), time.Second}, config{big.NewInt(2), time.Minute})).Should(Equal([]Difference{
+			{Path: ".Limit", Actual: "1", Expected: "2"},
+			{Path: ".Wait", Actual: "1s", Expected: "1m0s"},
+		}))
+	})
+
+	Context("when UseStdlibRepresentation is false", func() {
+		BeforeEach(func() {
+			UseStdlibRepresentation = false
+		})
+
+		AfterEach(func() {
+			UseStdlibRepresentation = true
+		})
+
+		It("should print them like any other value", func() {
+			Expect(Object(1500*time.Millisecond, 1)).Should(Equal("    <time.Duration>: 1500000000"))
+			Expect(Object(errorWithFields{Code: 7}, 1)).Should(Equal("    <format_test.errorWithFields>: {Code: 7}"))
+		})
+	})
+})
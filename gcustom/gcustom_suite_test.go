@@ -0,0 +1,13 @@
+package gcustom_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestGcustom(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Gcustom Suite")
+}
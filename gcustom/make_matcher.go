@@ -0,0 +1,225 @@
+/*
+Package gcustom builds custom matchers from plain functions, without the boilerplate of implementing
+types.GomegaMatcher by hand:
+
+	func BeAValidUser() types.GomegaMatcher {
+	    return gcustom.MakeMatcher(func(user User) (bool, error) {
+	        return user.Name != "" && user.Age >= 0, nil
+	    }).WithMessage("to be a valid user named {{.Name}}")
+	}
+
+	Expect(user).To(BeAValidUser())
+
+Failure messages can also be built from a template with access to the actual value and to additional data.  See
+WithTemplate.
+*/
+package gcustom
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+
+	"github.com/onsi/gomega/format"
+)
+
+var messageTemplate = template.Must(ParseTemplate("Expected:\n{{.FormattedActual}}\n{{.To}} {{.Data}}"))
+
+/*
+ParseTemplate parses a failure message template.  format.Object is available to the template as "format":
+
+	{{format .Data 1}}
+*/
+func ParseTemplate(text string) (*template.Template, error) {
+	return template.New("message").Funcs(template.FuncMap{
+		"format": format.Object,
+	}).Parse(text)
+}
+
+/*
+CustomGomegaMatcher is the types.GomegaMatcher built by MakeMatcher.  Its With... methods return a modified copy of
+the matcher, so a CustomGomegaMatcher can be shared and specialised freely.
+*/
+type CustomGomegaMatcher[T any] struct {
+	matchFunc  func(actual T) (bool, error)
+	oracleFunc func(actual T) bool
+	template   *template.Template
+	message    *template.Template
+	data       interface{}
+}
+
+/*
+MakeMatcher returns a matcher that calls matchFunc with the actual value.  The matcher fails with an error if the
+actual value is not a T; a nil actual is passed to matchFunc as the zero value of T.
+
+Until a message is set with WithMessage or WithTemplate, the matcher's failure messages read:
+
+	Expected:
+	    <actual>
+	to match
+*/
+func MakeMatcher[T any](matchFunc func(actual T) (bool, error)) CustomGomegaMatcher[T] {
+	return CustomGomegaMatcher[T]{
+		matchFunc: matchFunc,
+		template:  messageTemplate,
+		data:      "match",
+	}
+}
+
+/*
+WithMessage sets the message that follows the actual value in failure messages.  The "to" or "not to" in front of it
+is added for you, so a leading "to " is dropped from message:
+
+	gcustom.MakeMatcher(isValidRole).WithMessage("to be a valid {{.Name}}")
+
+reads, for a Role whose Name is "editor",
+
+	Expected:
+	    <actual>
+	to be a valid editor
+
+and
+
+	Expected:
+	    <actual>
+	not to be a valid editor
+
+The message must not start with "not to": that wording belongs to the negated failure message.
+
+The message is itself a template.  It is executed with data, if given, and otherwise with the actual value.  As with
+WithTemplate, format.Object is available as "format".
+
+WithMessage panics if the message cannot be parsed.
+*/
+func (m CustomGomegaMatcher[T]) WithMessage(message string, data ...interface{}) CustomGomegaMatcher[T] {
+	message = strings.TrimPrefix(message, "to ")
+	m.template = messageTemplate
+	m.message = template.Must(ParseTemplate(message))
+	m.data = nil
+	if len(data) > 0 {
+		m.data = data[0]
+	}
+	return m
+}
+
+/*
+WithTemplate sets a template that renders the matcher's failure messages.  The template is executed with a
+TemplateData, so it can refer to the actual value and to data:
+
+	gcustom.MakeMatcher(func(user User) (bool, error) {
+	    return user.Role == role, nil
+	}).WithTemplate("Expected:\n{{.FormattedActual}}\n{{.To}} have role {{.Data}}", role)
+
+{{.To}} renders as "to" in the failure message and as "not to" in the negated failure message.
+
+WithTemplate panics if the template cannot be parsed.
+*/
+func (m CustomGomegaMatcher[T]) WithTemplate(text string, data ...interface{}) CustomGomegaMatcher[T] {
+	return m.WithPrecompiledTemplate(template.Must(ParseTemplate(text)), data...)
+}
+
+/*
+WithPrecompiledTemplate is WithTemplate for a template that has already been parsed, with ParseTemplate for example.
+*/
+func (m CustomGomegaMatcher[T]) WithPrecompiledTemplate(tmpl *template.Template, data ...interface{}) CustomGomegaMatcher[T] {
+	m.template = tmpl
+	m.message = nil
+	m.data = nil
+	if len(data) > 0 {
+		m.data = data[0]
+	}
+	return m
+}
+
+/*
+WithOracle makes the matcher an OracleMatcher: Eventually and Consistently stop polling as soon as mayChange returns
+false for the actual value, because the outcome of the match can no longer change.
+*/
+func (m CustomGomegaMatcher[T]) WithOracle(mayChange func(actual T) bool) CustomGomegaMatcher[T] {
+	m.oracleFunc = mayChange
+	return m
+}
+
+func (m CustomGomegaMatcher[T]) Match(actual interface{}) (success bool, err error) {
+	typedActual, ok := m.typedActual(actual)
+	if !ok {
+		var zero T
+		return false, fmt.Errorf("Matcher expected actual of type <%s>.  Got:\n%s", reflect.TypeOf(&zero).Elem(), format.Object(actual, 1))
+	}
+	return m.matchFunc(typedActual)
+}
+
+func (m CustomGomegaMatcher[T]) FailureMessage(actual interface{}) (message string) {
+	return m.render(actual, true)
+}
+
+func (m CustomGomegaMatcher[T]) NegatedFailureMessage(actual interface{}) (message string) {
+	return m.render(actual, false)
+}
+
+func (m CustomGomegaMatcher[T]) MatchMayChangeInTheFuture(actual interface{}) bool {
+	if m.oracleFunc == nil {
+		return true
+	}
+	typedActual, ok := m.typedActual(actual)
+	if !ok {
+		return false
+	}
+	return m.oracleFunc(typedActual)
+}
+
+func (m CustomGomegaMatcher[T]) typedActual(actual interface{}) (T, bool) {
+	if actual == nil {
+		var zero T
+		return zero, true
+	}
+	typedActual, ok := actual.(T)
+	return typedActual, ok
+}
+
+/*
+TemplateData is what failure message templates are executed with
+*/
+type TemplateData struct {
+	//Failure is true when rendering the failure message and false when rendering the negated failure message
+	Failure bool
+	//To is "to" when rendering the failure message and "not to" when rendering the negated failure message
+	To string
+	//Actual is the actual value, FormattedActual is the actual value as formatted by format.Object
+	Actual          interface{}
+	FormattedActual string
+	//Data is the data passed to WithTemplate
+	Data interface{}
+}
+
+func (m CustomGomegaMatcher[T]) render(actual interface{}, failure bool) string {
+	data := TemplateData{
+		Failure:         failure,
+		To:              "to",
+		Actual:          actual,
+		FormattedActual: format.Object(actual, 1),
+		Data:            m.data,
+	}
+	if !failure {
+		data.To = "not to"
+	}
+
+	buffer := &bytes.Buffer{}
+	if m.message != nil {
+		dot := m.data
+		if dot == nil {
+			dot = actual
+		}
+		if err := m.message.Execute(buffer, dot); err != nil {
+			return fmt.Sprintf("Failed to render failure message template: %s", err.Error())
+		}
+		data.Data = buffer.String()
+		buffer.Reset()
+	}
+	if err := m.template.Execute(buffer, data); err != nil {
+		return fmt.Sprintf("Failed to render failure message template: %s", err.Error())
+	}
+	return buffer.String()
+}
@@ -0,0 +1,161 @@
+package gcustom_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gcustom"
+)
+
+type user struct {
+	Name string
+	Age  int
+}
+
+func beAnAdult() gcustom.CustomGomegaMatcher[user] {
+	return gcustom.MakeMatcher(func(u user) (bool, error) {
+		return u.Age >= 18, nil
+	})
+}
+
+var _ = Describe("MakeMatcher", func() {
+	Describe("matching", func() {
+		It("should call the match function with the typed actual value", func() {
+			Expect(user{Name: "Bob", Age: 30}).To(beAnAdult())
+			Expect(user{Name: "Tim", Age: 7}).NotTo(beAnAdult())
+		})
+
+		It("should pass a nil actual as the zero value", func() {
+			matcher := gcustom.MakeMatcher(func(u *user) (bool, error) {
+				return u == nil, nil
+			})
+			Expect(nil).To(matcher)
+		})
+
+		It("should return the match function's error", func() {
+			matcher := gcustom.MakeMatcher(func(u user) (bool, error) {
+				return false, errors.New("boom")
+			})
+			_, err := matcher.Match(user{})
+			Expect(err).To(MatchError("boom"))
+		})
+
+		It("should error when the actual value has the wrong type", func() {
+			_, err := beAnAdult().Match("Bob")
+			Expect(err).To(MatchError(ContainSubstring("Matcher expected actual of type <gcustom_test.user>.  Got:\n    <string>: Bob")))
+		})
+
+		It("should work with interface types", func() {
+			matcher := gcustom.MakeMatcher(func(err error) (bool, error) {
+				return err != nil && err.Error() == "boom", nil
+			})
+			Expect(errors.New("boom")).To(matcher)
+			Expect(nil).NotTo(matcher)
+		})
+	})
+
+	Describe("failure messages", func() {
+		It("should default to a generic message", func() {
+			Expect(beAnAdult().FailureMessage(user{Name: "Tim", Age: 7})).To(HavePrefix("Expected:\n    <gcustom_test.user>: {Name: \"Tim\", Age: 7}\nto match"))
+			Expect(beAnAdult().NegatedFailureMessage(user{Name: "Tim", Age: 7})).To(HaveSuffix("\nnot to match"))
+		})
+
+		It("should build both messages from WithMessage", func() {
+			matcher := beAnAdult().WithMessage("be an adult")
+			Expect(matcher.FailureMessage(user{Name: "Tim", Age: 7})).To(Equal("Expected:\n    <gcustom_test.user>: {Name: \"Tim\", Age: 7}\nto be an adult"))
+			Expect(matcher.NegatedFailureMessage(user{Name: "Tim", Age: 7})).To(Equal("Expected:\n    <gcustom_test.user>: {Name: \"Tim\", Age: 7}\nnot to be an adult"))
+		})
+
+		It("should drop a leading \"to\" from the message", func() {
+			matcher := beAnAdult().WithMessage("to be an adult")
+			Expect(matcher.FailureMessage(user{Name: "Tim", Age: 7})).To(HaveSuffix("\nto be an adult"))
+			Expect(matcher.NegatedFailureMessage(user{Name: "Tim", Age: 7})).To(HaveSuffix("\nnot to be an adult"))
+		})
+
+		It("should render the message as a template with the actual value", func() {
+			type role struct {
+				Name string
+			}
+			matcher := gcustom.MakeMatcher(func(r role) (bool, error) {
+				return r.Name == "admin" || r.Name == "viewer", nil
+			}).WithMessage("to be a valid {{.Name}}")
+			Expect(matcher.FailureMessage(role{Name: "root"})).To(Equal("Expected:\n    <gcustom_test.role>: {Name: \"root\"}\nto be a valid root"))
+			Expect(matcher.NegatedFailureMessage(role{Name: "admin"})).To(Equal("Expected:\n    <gcustom_test.role>: {Name: \"admin\"}\nnot to be a valid admin"))
+		})
+
+		It("should render the message as a template with data when given", func() {
+			matcher := beAnAdult().WithMessage("be at least {{.}} years old", 18)
+			Expect(matcher.FailureMessage(user{Name: "Tim", Age: 7})).To(HaveSuffix("\nto be at least 18 years old"))
+		})
+
+		It("should panic when the message cannot be parsed", func() {
+			Expect(func() { beAnAdult().WithMessage("be {{") }).To(Panic())
+		})
+
+		It("should render templates with the actual value and data", func() {
+			matcher := beAnAdult().WithTemplate("{{.Actual.Name}} is {{.Actual.Age}}, which is {{if .Failure}}under{{else}}at least{{end}} {{.Data}}", 18)
+			Expect(matcher.FailureMessage(user{Name: "Tim", Age: 7})).To(Equal("Tim is 7, which is under 18"))
+			Expect(matcher.NegatedFailureMessage(user{Name: "Bob", Age: 30})).To(Equal("Bob is 30, which is at least 18"))
+		})
+
+		It("should provide To and FormattedActual", func() {
+			matcher := beAnAdult().WithTemplate("Expected:\n{{.FormattedActual}}\n{{.To}} be at least {{.Data}}", 18)
+			Expect(matcher.NegatedFailureMessage(user{Name: "Bob", Age: 30})).To(Equal("Expected:\n    <gcustom_test.user>: {Name: \"Bob\", Age: 30}\nnot to be at least 18"))
+		})
+
+		It("should make format available to templates", func() {
+			matcher := beAnAdult().WithTemplate("{{format .Data 0}}", []int{18})
+			Expect(matcher.FailureMessage(user{})).To(Equal("<[]int | len:1, cap:1>: [18]"))
+		})
+
+		It("should accept precompiled templates", func() {
+			tmpl, err := gcustom.ParseTemplate("{{.To}} be {{.Data}}")
+			Expect(err).NotTo(HaveOccurred())
+			matcher := beAnAdult().WithPrecompiledTemplate(tmpl, "grown up")
+			Expect(matcher.NegatedFailureMessage(user{})).To(Equal("not to be grown up"))
+		})
+
+		It("should report templates that fail to render", func() {
+			matcher := beAnAdult().WithTemplate("{{.Data.Missing}}", 3)
+			Expect(matcher.FailureMessage(user{})).To(HavePrefix("Failed to render failure message template:"))
+		})
+
+		It("should panic when the template does not parse", func() {
+			Expect(func() { beAnAdult().WithTemplate("{{.Data") }).To(Panic())
+			_, err := gcustom.ParseTemplate("{{.Data")
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should not modify the matcher it was called on", func() {
+			matcher := beAnAdult()
+			matcher.WithMessage("be an adult")
+			Expect(matcher.FailureMessage(user{})).To(HaveSuffix("\nto match"))
+		})
+	})
+
+	Describe("WithOracle", func() {
+		It("should say the match may change unless an oracle is given", func() {
+			Expect(beAnAdult().MatchMayChangeInTheFuture(user{})).To(BeTrue())
+		})
+
+		It("should ask the oracle otherwise", func() {
+			matcher := beAnAdult().WithOracle(func(u user) bool {
+				return u.Name != "final"
+			})
+			Expect(matcher.MatchMayChangeInTheFuture(user{Name: "final"})).To(BeFalse())
+			Expect(matcher.MatchMayChangeInTheFuture(user{Name: "Tim"})).To(BeTrue())
+			Expect(matcher.MatchMayChangeInTheFuture("not a user")).To(BeFalse())
+		})
+
+		It("should let Eventually stop polling early", func() {
+			matcher := beAnAdult().WithOracle(func(u user) bool { return false })
+			failures := InterceptGomegaFailures(func() {
+				Eventually(user{Name: "Tim", Age: 7}, time.Second).Should(matcher)
+			})
+			Expect(failures).To(HaveLen(1))
+			Expect(failures[0]).To(ContainSubstring("No future change is possible."))
+		})
+	})
+})
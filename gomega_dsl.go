@@ -14,8 +14,6 @@ Gomega is MIT-Licensed
 package gomega
 
 import (
-	"fmt"
-	"reflect"
 	"time"
 
 	"github.com/onsi/gomega/internal/assertion"
@@ -34,10 +32,75 @@ Depending on your vendoring solution you may be inadvertently importing gomega a
 
 var globalFailWrapper *types.GomegaFailWrapper
 
-var defaultEventuallyTimeout = time.Second
-var defaultEventuallyPollingInterval = 10 * time.Millisecond
-var defaultConsistentlyDuration = 100 * time.Millisecond
-var defaultConsistentlyPollingInterval = 10 * time.Millisecond
+// globalReporter is carried over to the global fail wrapper whenever a fail handler is registered
+var globalReporter types.AssertionReporter
+
+// asyncDefaults holds the settings Eventually and Consistently use when they are not given explicitly.
+// The package-level DSL uses globalAsyncDefaults; a WithT uses its own copy once it has been configured.
+type asyncDefaults struct {
+	eventuallyTimeout           time.Duration
+	eventuallyPollingInterval   time.Duration
+	consistentlyDuration        time.Duration
+	consistentlyPollingInterval time.Duration
+	timeline                    bool
+	pollingStrategy             PollingStrategy
+	clock                       Clock
+}
+
+var globalAsyncDefaults = &asyncDefaults{
+	eventuallyTimeout:           time.Second,
+	eventuallyPollingInterval:   10 * time.Millisecond,
+	consistentlyDuration:        100 * time.Millisecond,
+	consistentlyPollingInterval: 10 * time.Millisecond,
+}
+
+func (d *asyncDefaults) eventually(actual interface{}, failWrapper *types.GomegaFailWrapper, offset int, intervals ...interface{}) AsyncAssertion {
+	assertion := asyncassertion.NewWithIntervals(asyncassertion.AsyncAssertionTypeEventually, actual, failWrapper, d.eventuallyTimeout, d.eventuallyPollingInterval, offset, intervals...)
+	return assertion.WithTimeline(d.timeline).WithPollingStrategy(d.pollingStrategy).WithClock(d.clock)
+}
+
+func (d *asyncDefaults) consistently(actual interface{}, failWrapper *types.GomegaFailWrapper, offset int, intervals ...interface{}) AsyncAssertion {
+	assertion := asyncassertion.NewWithIntervals(asyncassertion.AsyncAssertionTypeConsistently, actual, failWrapper, d.consistentlyDuration, d.consistentlyPollingInterval, offset, intervals...)
+	return assertion.WithTimeline(d.timeline).WithPollingStrategy(d.pollingStrategy).WithClock(d.clock)
+}
+
+// GomegaOption configures the defaults of a Gomega instance created with NewGomega or configured with WithT.ConfigureWithDefaults.
+type GomegaOption func(*asyncDefaults)
+
+// WithDefaultEventuallyTimeout sets the instance's default timeout for Eventually.  See SetDefaultEventuallyTimeout.
+func WithDefaultEventuallyTimeout(t time.Duration) GomegaOption {
+	return func(d *asyncDefaults) { d.eventuallyTimeout = t }
+}
+
+// WithDefaultEventuallyPollingInterval sets the instance's default polling interval for Eventually.  See SetDefaultEventuallyPollingInterval.
+func WithDefaultEventuallyPollingInterval(t time.Duration) GomegaOption {
+	return func(d *asyncDefaults) { d.eventuallyPollingInterval = t }
+}
+
+// WithDefaultConsistentlyDuration sets the instance's default duration for Consistently.  See SetDefaultConsistentlyDuration.
+func WithDefaultConsistentlyDuration(t time.Duration) GomegaOption {
+	return func(d *asyncDefaults) { d.consistentlyDuration = t }
+}
+
+// WithDefaultConsistentlyPollingInterval sets the instance's default polling interval for Consistently.  See SetDefaultConsistentlyPollingInterval.
+func WithDefaultConsistentlyPollingInterval(t time.Duration) GomegaOption {
+	return func(d *asyncDefaults) { d.consistentlyPollingInterval = t }
+}
+
+// WithDefaultAsyncTimeline turns the poll timeline on (or off) for the instance's Eventually and Consistently.  See SetDefaultAsyncTimeline.
+func WithDefaultAsyncTimeline(enabled bool) GomegaOption {
+	return func(d *asyncDefaults) { d.timeline = enabled }
+}
+
+// WithDefaultPollingStrategy sets the instance's PollingStrategy.  See SetDefaultPollingStrategy.
+func WithDefaultPollingStrategy(strategy PollingStrategy) GomegaOption {
+	return func(d *asyncDefaults) { d.pollingStrategy = strategy }
+}
+
+// WithDefaultClock sets the Clock used by the instance's Eventually and Consistently.  See SetDefaultClock.
+func WithDefaultClock(clock Clock) GomegaOption {
+	return func(d *asyncDefaults) { d.clock = clock }
+}
 
 // RegisterFailHandler connects Ginkgo to Gomega. When a matcher fails
 // the fail handler passed into RegisterFailHandler is called.
@@ -55,10 +118,58 @@ func RegisterFailHandlerWithT(t types.TWithHelper, handler types.GomegaFailHandl
 
 	globalFailWrapper = &types.GomegaFailWrapper{
 		Fail:        handler,
+		Reporter:    globalReporter,
 		TWithHelper: t,
 	}
 }
 
+// RegisterFailureHandler registers a structured fail handler globally.  Instead of a flattened message the handler
+// receives a types.Failure carrying the failure's raw values - the actual and expected values, the matcher, the
+// description and the location - which is useful for exporting failures:
+//
+//    RegisterFailureHandler(func(failure types.Failure, callerSkip ...int) {
+//        dashboard.Record(failure.Location.String(), failure.Actual, failure.Expected)
+//        ginkgo.Fail(failure.Message, callerSkip[0]+1)
+//    })
+//
+// The handler replaces any handler registered with RegisterFailHandler, so it is responsible for failing the test.
+func RegisterFailureHandler(handler types.GomegaFailureHandler) {
+	if handler == nil {
+		globalFailWrapper = nil
+		return
+	}
+
+	globalFailWrapper = &types.GomegaFailWrapper{
+		Fail:           failHandlerFor(handler),
+		FailureHandler: handler,
+		Reporter:       globalReporter,
+		TWithHelper:    testingtsupport.EmptyTWithHelper{},
+	}
+}
+
+// AttachReporter attaches an assertion reporter to the global fail handler.  The reporter is told the result of every
+// assertion made with the global Expect, Eventually and Consistently, and stays attached when a different fail handler
+// is registered.  Pass nil to detach it.
+//
+// Package greporter provides reporters that write JSON Lines and JUnit XML.
+func AttachReporter(reporter types.AssertionReporter) {
+	globalReporter = reporter
+	if globalFailWrapper != nil {
+		globalFailWrapper.Reporter = reporter
+	}
+}
+
+// failHandlerFor adapts a structured fail handler for failures that only have a message
+func failHandlerFor(handler types.GomegaFailureHandler) types.GomegaFailHandler {
+	return func(message string, callerSkip ...int) {
+		skip := 0
+		if len(callerSkip) > 0 {
+			skip = callerSkip[0]
+		}
+		handler(types.Failure{Message: message}, skip+1)
+	}
+}
+
 // RegisterTestingT connects Gomega to Golang's XUnit style
 // Testing.T tests.  It is now deprecated and you should use NewWithT() instead.
 //
@@ -99,13 +210,16 @@ func RegisterTestingT(t types.GomegaTestingT) {
 // This is most useful when testing custom matchers, but can also be used to check
 // on a value using a Gomega assertion without causing a test failure.
 func InterceptGomegaFailures(f func()) []string {
-	originalHandler := globalFailWrapper.Fail
+	originalFailWrapper := globalFailWrapper
 	failures := []string{}
-	RegisterFailHandler(func(message string, callerSkip ...int) {
-		failures = append(failures, message)
-	})
+	globalFailWrapper = &types.GomegaFailWrapper{
+		Fail: func(message string, callerSkip ...int) {
+			failures = append(failures, message)
+		},
+		TWithHelper: testingtsupport.EmptyTWithHelper{},
+	}
 	f()
-	RegisterFailHandler(originalHandler)
+	globalFailWrapper = originalFailWrapper
 	return failures
 }
 
@@ -167,6 +281,32 @@ func ExpectWithOffset(offset int, actual interface{}, extra ...interface{}) Asse
 	return assertion.New(actual, globalFailWrapper, offset, extra...)
 }
 
+// ExpectReturns wraps the return values of a function, allowing each of them to be matched against the matcher in the
+// same position.  Given a function with signature:
+//    func FetchRecord() (int, string, error)
+//
+// Then:
+//    ExpectReturns(FetchRecord()).To(Equal(3), HavePrefix("x"), Succeed())
+// Will succeed only if all three return values match.  The failure message names the index of the first return value
+// that did not.  ShouldNot, ToNot and NotTo succeed if any return value does not match.
+//
+// Since the matchers are passed variadically, an optional description is given with WithDescription:
+//    ExpectReturns(FetchRecord()).WithDescription("record %d", id).To(Equal(3), HavePrefix("x"), Succeed())
+//
+// To poll such a function, use Eventually with the ReturnValues matcher.
+func ExpectReturns(actual ...interface{}) ReturnValuesAssertion {
+	return ExpectReturnsWithOffset(0, actual...)
+}
+
+// ExpectReturnsWithOffset is ExpectReturns with an additional integer argument that is used to modify the call-stack
+// offset when computing line numbers.  See ExpectWithOffset.
+func ExpectReturnsWithOffset(offset int, actual ...interface{}) ReturnValuesAssertion {
+	if globalFailWrapper == nil {
+		panic(nilFailHandlerPanic)
+	}
+	return assertion.NewReturnValues(actual, globalFailWrapper, offset)
+}
+
 // Eventually wraps an actual value allowing assertions to be made on it.
 // The assertion is tried periodically until it passes or a timeout occurs.
 //
@@ -175,7 +315,17 @@ func ExpectWithOffset(offset int, actual interface{}, extra ...interface{}) Asse
 // The second optional argument is the polling interval
 //
 // Both intervals can either be specified as time.Duration, parsable duration strings or as floats/integers.  In the
-// last case they are interpreted as seconds.
+// last case they are interpreted as seconds.  They can also be set by chaining methods on the returned AsyncAssertion:
+//
+//    Eventually(thingImPolling.Count).WithTimeout(5 * time.Second).WithPolling(100 * time.Millisecond).Should(BeNumerically(">=", 17))
+//    Eventually(thingImPolling.Count).Within(5 * time.Second).ProbeEvery(100 * time.Millisecond).Should(BeNumerically(">=", 17))
+//
+// A context.Context may also be passed alongside the intervals.  Eventually stops polling and fails as soon as the
+// context is cancelled, rather than waiting for its timeout:
+//
+//    Eventually(thingImPolling.Count, ctx).Should(BeNumerically(">=", 17))
+//
+// The same can be achieved with Eventually(...).WithContext(ctx).
 //
 // If Eventually is passed an actual that is a function taking no arguments and returning at least one value,
 // then Eventually will call the function periodically and try the matcher against the function's first return value.
@@ -202,6 +352,26 @@ func ExpectWithOffset(offset int, actual interface{}, extra ...interface{}) Asse
 //
 // Will pass only if the the returned error is nil and the returned string passes the matcher.
 //
+// To match every return value instead, use the ReturnValues matcher:
+//    Eventually(FetchFromDB).Should(ReturnValues(HavePrefix("hassel"), Succeed()))
+//
+// Eventually can also be passed a function that takes a single Gomega argument and returns zero or more values.
+// Eventually passes a Gomega to the function on every poll.  Any assertion made with that Gomega that fails stops the
+// function and counts as a failed poll; if Eventually times out the last such failure is included in its failure message.
+// This lets you poll several related conditions together:
+//
+//    Eventually(func(g Gomega) {
+//        model, err := client.Find(1138)
+//        g.Expect(err).NotTo(HaveOccurred())
+//        g.Expect(model.Reticulated()).To(BeTrue())
+//    }).Should(Succeed())
+//
+// If the function also returns values, the first is passed to the matcher as usual once all assertions pass.
+// Eventually and Consistently called on the passed-in Gomega default to the enclosing assertion's timeout and polling interval.
+//
+// When Eventually is passed a channel and a Receive matcher it receives from the channel itself: it waits for each value to
+// arrive instead of polling, hands every value to the matcher, and on failure reports how many values arrived and the last one.
+//
 // Eventually's default timeout is 1 second, and its default polling interval is 10ms
 func Eventually(actual interface{}, intervals ...interface{}) AsyncAssertion {
 	return EventuallyWithOffset(0, actual, intervals...)
@@ -214,15 +384,7 @@ func EventuallyWithOffset(offset int, actual interface{}, intervals ...interface
 	if globalFailWrapper == nil {
 		panic(nilFailHandlerPanic)
 	}
-	timeoutInterval := defaultEventuallyTimeout
-	pollingInterval := defaultEventuallyPollingInterval
-	if len(intervals) > 0 {
-		timeoutInterval = toDuration(intervals[0])
-	}
-	if len(intervals) > 1 {
-		pollingInterval = toDuration(intervals[1])
-	}
-	return asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, actual, globalFailWrapper, timeoutInterval, pollingInterval, offset)
+	return globalAsyncDefaults.eventually(actual, globalFailWrapper, offset, intervals...)
 }
 
 // Consistently wraps an actual value allowing assertions to be made on it.
@@ -235,6 +397,9 @@ func EventuallyWithOffset(offset int, actual interface{}, intervals ...interface
 // Both intervals can either be specified as time.Duration, parsable duration strings or as floats/integers.  In the
 // last case they are interpreted as seconds.
 //
+// As with Eventually, a context.Context may be passed alongside the intervals.  Consistently fails if the context
+// is cancelled before its duration has elapsed.
+//
 // If Consistently is passed an actual that is a function taking no arguments and returning at least one value,
 // then Consistently will call the function periodically and try the matcher against the function's first return value.
 //
@@ -242,6 +407,9 @@ func EventuallyWithOffset(offset int, actual interface{}, intervals ...interface
 // assert that all other values are nil/zero.
 // This allows you to pass Consistently a function that returns a value and an error - a common pattern in Go.
 //
+// Like Eventually, Consistently can be passed a function that takes a Gomega.  A failed assertion made with that Gomega
+// fails Consistently.
+//
 // Consistently is useful in cases where you want to assert that something *does not happen* over a period of time.
 // For example, you want to assert that a goroutine does *not* send data down a channel.  In this case, you could:
 //
@@ -259,37 +427,106 @@ func ConsistentlyWithOffset(offset int, actual interface{}, intervals ...interfa
 	if globalFailWrapper == nil {
 		panic(nilFailHandlerPanic)
 	}
-	timeoutInterval := defaultConsistentlyDuration
-	pollingInterval := defaultConsistentlyPollingInterval
-	if len(intervals) > 0 {
-		timeoutInterval = toDuration(intervals[0])
-	}
-	if len(intervals) > 1 {
-		pollingInterval = toDuration(intervals[1])
-	}
-	return asyncassertion.New(asyncassertion.AsyncAssertionTypeConsistently, actual, globalFailWrapper, timeoutInterval, pollingInterval, offset)
+	return globalAsyncDefaults.consistently(actual, globalFailWrapper, offset, intervals...)
 }
 
+// StopTrying returns an error that a function polled by Eventually or Consistently can return, or panic with, to stop
+// polling immediately.  The assertion then fails with the given message and the last value the function returned.
+//
+// This is useful when the thing being polled reaches a state from which the assertion can never pass:
+//
+//    Eventually(func() (string, error) {
+//        status := job.Status()
+//        if status == "Failed" {
+//            return status, StopTrying("the job failed")
+//        }
+//        return status, nil
+//    }).Should(Equal("Succeeded"))
+//
+// Use Wrap to attach an underlying error:
+//
+//    return StopTrying("could not reach the server").Wrap(err)
+func StopTrying(message string) *StopTryingError {
+	return asyncassertion.NewStopTryingError(message)
+}
+
+// StopTryingError is the error returned by StopTrying.
+type StopTryingError = asyncassertion.StopTryingError
+
 // SetDefaultEventuallyTimeout sets the default timeout duration for Eventually. Eventually will repeatedly poll your condition until it succeeds, or until this timeout elapses.
 func SetDefaultEventuallyTimeout(t time.Duration) {
-	defaultEventuallyTimeout = t
+	globalAsyncDefaults.eventuallyTimeout = t
 }
 
 // SetDefaultEventuallyPollingInterval sets the default polling interval for Eventually.
 func SetDefaultEventuallyPollingInterval(t time.Duration) {
-	defaultEventuallyPollingInterval = t
+	globalAsyncDefaults.eventuallyPollingInterval = t
 }
 
 // SetDefaultConsistentlyDuration sets  the default duration for Consistently. Consistently will verify that your condition is satisfied for this long.
 func SetDefaultConsistentlyDuration(t time.Duration) {
-	defaultConsistentlyDuration = t
+	globalAsyncDefaults.consistentlyDuration = t
 }
 
 // SetDefaultConsistentlyPollingInterval sets the default polling interval for Consistently.
 func SetDefaultConsistentlyPollingInterval(t time.Duration) {
-	defaultConsistentlyPollingInterval = t
+	globalAsyncDefaults.consistentlyPollingInterval = t
+}
+
+// SetDefaultAsyncTimeline turns on (or off) the poll timeline for every Eventually and Consistently.  See AsyncAssertion.WithTimeline.
+func SetDefaultAsyncTimeline(enabled bool) {
+	globalAsyncDefaults.timeline = enabled
+}
+
+// SetDefaultPollingStrategy sets the PollingStrategy used by every Eventually and Consistently.  Pass nil to go back to
+// polling at a fixed interval.
+//
+// The default strategy can also be set with the GOMEGA_DEFAULT_POLLING_STRATEGY environment variable, to one of "fixed",
+// "exponential", "jittered" or "exponential-jittered".  Exponential backoff doubles the interval after every poll,
+// up to GOMEGA_DEFAULT_POLLING_MAX_INTERVAL (1s if unset).  Jitter varies each interval by up to 25%.
+func SetDefaultPollingStrategy(strategy PollingStrategy) {
+	globalAsyncDefaults.pollingStrategy = strategy
 }
 
+// PollingStrategy decides how long Eventually and Consistently wait between polls.  It is given the assertion's polling
+// interval and the number of polls made so far, and returns the time to wait before the next poll.
+//
+// Gomega provides FixedPolling, ExponentialBackoffPolling and JitteredPolling.  Use AsyncAssertion.WithPollingStrategy to
+// set a strategy for a single assertion, and SetDefaultPollingStrategy to set it for all of them.
+type PollingStrategy = types.PollingStrategy
+
+// FixedPolling waits for the polling interval between every poll.  This is the default.
+func FixedPolling() PollingStrategy {
+	return asyncassertion.FixedPollingStrategy{}
+}
+
+// ExponentialBackoffPolling starts by waiting for the polling interval, and multiplies the wait by factor after every
+// poll, up to maxInterval.  This is useful when polling a service that rate-limits or is expensive to query:
+//
+//    Eventually(client.Status).WithPollingStrategy(ExponentialBackoffPolling(2, time.Second)).Should(Equal("ready"))
+func ExponentialBackoffPolling(factor float64, maxInterval time.Duration) PollingStrategy {
+	return asyncassertion.ExponentialBackoffPollingStrategy{Factor: factor, MaxInterval: maxInterval}
+}
+
+// JitteredPolling randomly lengthens or shortens each interval chosen by strategy by up to fraction of its length,
+// so that many assertions polling the same service do not poll in lockstep.
+func JitteredPolling(strategy PollingStrategy, fraction float64) PollingStrategy {
+	return asyncassertion.JitteredPollingStrategy{Strategy: strategy, Fraction: fraction}
+}
+
+// SetDefaultClock sets the Clock used to time every Eventually and Consistently.  Pass nil to go back to the real clock.
+// WithT.SetClock overrides the default for a single WithT.
+func SetDefaultClock(clock Clock) {
+	globalAsyncDefaults.clock = clock
+}
+
+// Clock is the source of time used by Eventually and Consistently: they take their start time, timeout and polling
+// ticks from it, and report elapsed time in failure messages according to it.
+//
+// Setting a fake Clock lets a test move time forward deterministically while an assertion polls, for example to test
+// code that expires entries after an hour without waiting an hour.
+type Clock = types.Clock
+
 // AsyncAssertion is returned by Eventually and Consistently and polls the actual value passed into Eventually against
 // the matcher passed to the Should and ShouldNot methods.
 //
@@ -301,14 +538,41 @@ func SetDefaultConsistentlyPollingInterval(t time.Duration) {
 //
 // Both Should and ShouldNot return a boolean that is true if the assertion passed and false if it failed.
 //
+// WithContext(ctx) makes the assertion stop polling, and fail, as soon as ctx is cancelled.
+//
+// WithTimeline(true) records every poll and appends a compact timeline to the failure message, e.g.:
+//
+//   Timeline:
+//     t+0.00s..t+0.04s: 3 items (no match) x5
+//     t+0.05s: 4 items (no match)
+//     t+0.06s..t+0.10s: 3 items (no match) x5
+//
+// Consecutive polls with the same value and result are collapsed into one line.  SetDefaultAsyncTimeline turns it on for every assertion.
+//
+// WithPollingStrategy(strategy) changes how the time between polls evolves; see PollingStrategy.
+//
+// MustPassRepeatedly(n) makes Eventually pass only after n consecutive polls pass; any poll that does not pass starts
+// the count over.  This is useful for conditions that flap:
+//
+//   Eventually(service.Healthy).MustPassRepeatedly(5).Should(BeTrue())
+//
+// If Eventually times out, the failure message reports the longest run of consecutive passes.  Consistently does not
+// support MustPassRepeatedly.
+//
+// A panic in a polled function is recovered and counts as a poll that did not match, so Eventually keeps polling; if
+// it times out, the failure message shows the last panic's value and stack.  WithFailOnPanic(true) fails the assertion
+// at the first panic instead.  Panics raised by a fail handler, such as Ginkgo's Fail after a failed global Expect in the
+// polled function, are never recovered: the test stops as it would outside Eventually.
+//
+// The timeout and polling interval can also be set by chaining WithTimeout and WithPolling (or their aliases Within and
+// ProbeEvery) instead of passing them positionally.  Invalid durations fail the assertion with a description of the problem.
+//
 // Example:
 //
 //   Eventually(myChannel).Should(Receive(), "Something should have come down the pipe.")
 //   Consistently(myChannel).ShouldNot(Receive(), func() string { return "Nothing should have come down the pipe." })
-type AsyncAssertion interface {
-	Should(matcher types.GomegaMatcher, optionalDescription ...interface{}) bool
-	ShouldNot(matcher types.GomegaMatcher, optionalDescription ...interface{}) bool
-}
+//   Eventually(myChannel).WithContext(ctx).Should(Receive())
+type AsyncAssertion = types.AsyncAssertion
 
 // GomegaAsyncAssertion is deprecated in favor of AsyncAssertion, which does not stutter.
 type GomegaAsyncAssertion = AsyncAssertion
@@ -330,14 +594,11 @@ type GomegaAsyncAssertion = AsyncAssertion
 // Example:
 //
 //    Ω(farm.HasCow()).Should(BeTrue(), "Farm %v should have a cow", farm)
-type Assertion interface {
-	Should(matcher types.GomegaMatcher, optionalDescription ...interface{}) bool
-	ShouldNot(matcher types.GomegaMatcher, optionalDescription ...interface{}) bool
+type Assertion = types.Assertion
 
-	To(matcher types.GomegaMatcher, optionalDescription ...interface{}) bool
-	ToNot(matcher types.GomegaMatcher, optionalDescription ...interface{}) bool
-	NotTo(matcher types.GomegaMatcher, optionalDescription ...interface{}) bool
-}
+// ReturnValuesAssertion is returned by ExpectReturns and matches each of a function's return values against the
+// matcher in the same position.  Its methods return true if all of them matched.
+type ReturnValuesAssertion = types.ReturnValuesAssertion
 
 // GomegaAssertion is deprecated in favor of Assertion, which does not stutter.
 type GomegaAssertion = Assertion
@@ -348,9 +609,14 @@ type OmegaMatcher types.GomegaMatcher
 // WithT wraps a *testing.T and provides `Expect`, `Eventually`, and `Consistently` methods.  This allows you to leverage
 // Gomega's rich ecosystem of matchers in standard `testing` test suites.
 //
-// Use `NewWithT` to instantiate a `WithT`
+// Use `NewWithT` to instantiate a `WithT`, or `NewGomega` to build one around any fail handler.
+// A WithT can carry its own defaults for Eventually and Consistently; see `ConfigureWithDefaults`.
 type WithT struct {
-	t types.GomegaTestingT
+	failWrapper *types.GomegaFailWrapper
+	defaults    *asyncDefaults
+
+	// goroutines started with Go, see goroutines.go
+	goroutines goroutineGroup
 }
 
 // GomegaWithT is deprecated in favor of gomega.WithT, which does not stutter.
@@ -366,9 +632,85 @@ type GomegaWithT = WithT
 //        g.Expect(f.HasCow()).To(BeTrue(), "Farm should have cow")
 //     }
 func NewWithT(t types.GomegaTestingT) *WithT {
-	return &WithT{
-		t: t,
+	g := &WithT{
+		failWrapper: testingtsupport.BuildTestingTGomegaFailWrapper(t),
+	}
+	if tWithCleanup, ok := t.(interface{ Cleanup(func()) }); ok {
+		g.goroutines.cleanup = tWithCleanup.Cleanup
+	}
+	return g
+}
+
+// NewGomega returns a Gomega instance that reports failures to the given fail handler.  Like a WithT, it carries its own
+// defaults for Eventually and Consistently, configured with GomegaOptions:
+//
+//    g := gomega.NewGomega(ginkgo.Fail, gomega.WithDefaultEventuallyTimeout(5*time.Second))
+//    g.Eventually(server.Ready).Should(BeTrue())
+//
+// Any defaults not set with an option are copied from the package-level defaults when NewGomega is called.
+func NewGomega(handler types.GomegaFailHandler, options ...GomegaOption) *WithT {
+	g := &WithT{
+		failWrapper: &types.GomegaFailWrapper{
+			Fail:        handler,
+			TWithHelper: testingtsupport.EmptyTWithHelper{},
+		},
+	}
+	return g.ConfigureWithDefaults(options...)
+}
+
+// NewGomegaWithFailureHandler is NewGomega for a structured fail handler.  See RegisterFailureHandler.
+func NewGomegaWithFailureHandler(handler types.GomegaFailureHandler, options ...GomegaOption) *WithT {
+	g := &WithT{
+		failWrapper: &types.GomegaFailWrapper{
+			Fail:           failHandlerFor(handler),
+			FailureHandler: handler,
+			TWithHelper:    testingtsupport.EmptyTWithHelper{},
+		},
 	}
+	return g.ConfigureWithDefaults(options...)
+}
+
+// ConfigureWithDefaults gives the WithT its own defaults for Eventually and Consistently,
+// leaving the package-level defaults untouched.  This lets parallel tests use different defaults without racing:
+//
+//    func TestSlowThing(t *testing.T) {
+//        t.Parallel()
+//        g := NewWithT(t).ConfigureWithDefaults(WithDefaultEventuallyTimeout(10*time.Second))
+//        g.Eventually(slowThing.Done).Should(BeTrue())
+//    }
+//
+// The first call copies the package-level defaults (those set with SetDefaultEventuallyTimeout and friends); from then on
+// the WithT no longer sees changes to them.  Until it is configured a WithT follows the package-level defaults.
+//
+// Settings in package format, such as format.MaxLength, are package-level and shared by every WithT.
+func (g *WithT) ConfigureWithDefaults(options ...GomegaOption) *WithT {
+	if g.defaults == nil {
+		defaults := *globalAsyncDefaults
+		g.defaults = &defaults
+	}
+	for _, option := range options {
+		option(g.defaults)
+	}
+	return g
+}
+
+// AttachReporter attaches an assertion reporter to the WithT.  The reporter is told the result of every assertion made
+// with the WithT.  Pass nil to detach it.  See the global AttachReporter.
+func (g *WithT) AttachReporter(reporter types.AssertionReporter) *WithT {
+	g.failWrapper.Reporter = reporter
+	return g
+}
+
+// SetClock sets the Clock used by this WithT's Eventually and Consistently, overriding the default set with SetDefaultClock.
+func (g *WithT) SetClock(clock Clock) {
+	g.ConfigureWithDefaults(WithDefaultClock(clock))
+}
+
+func (g *WithT) asyncDefaults() *asyncDefaults {
+	if g.defaults == nil {
+		return globalAsyncDefaults
+	}
+	return g.defaults
 }
 
 // NewGomegaWithT is deprecated in favor of gomega.NewWithT, which does not stutter.
@@ -378,33 +720,22 @@ func NewGomegaWithT(t types.GomegaTestingT) *GomegaWithT {
 
 // ExpectWithOffset is used to make assertions. See documentation for ExpectWithOffset.
 func (g *WithT) ExpectWithOffset(offset int, actual interface{}, extra ...interface{}) Assertion {
-	return assertion.New(actual, testingtsupport.BuildTestingTGomegaFailWrapper(g.t), offset, extra...)
+	return assertion.New(actual, g.failWrapper, offset, extra...)
+}
+
+// ExpectReturnsWithOffset is used to make assertions on return values. See documentation for ExpectReturnsWithOffset.
+func (g *WithT) ExpectReturnsWithOffset(offset int, actual ...interface{}) ReturnValuesAssertion {
+	return assertion.NewReturnValues(actual, g.failWrapper, offset)
 }
 
 // EventuallyWithOffset is used to make asynchronous assertions. See documentation for EventuallyWithOffset.
 func (g *WithT) EventuallyWithOffset(offset int, actual interface{}, intervals ...interface{}) AsyncAssertion {
-	timeoutInterval := defaultEventuallyTimeout
-	pollingInterval := defaultEventuallyPollingInterval
-	if len(intervals) > 0 {
-		timeoutInterval = toDuration(intervals[0])
-	}
-	if len(intervals) > 1 {
-		pollingInterval = toDuration(intervals[1])
-	}
-	return asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, actual, testingtsupport.BuildTestingTGomegaFailWrapper(g.t), timeoutInterval, pollingInterval, offset)
+	return g.asyncDefaults().eventually(actual, g.failWrapper, offset, intervals...)
 }
 
 // ConsistentlyWithOffset is used to make asynchronous assertions. See documentation for ConsistentlyWithOffset.
 func (g *WithT) ConsistentlyWithOffset(offset int, actual interface{}, intervals ...interface{}) AsyncAssertion {
-	timeoutInterval := defaultConsistentlyDuration
-	pollingInterval := defaultConsistentlyPollingInterval
-	if len(intervals) > 0 {
-		timeoutInterval = toDuration(intervals[0])
-	}
-	if len(intervals) > 1 {
-		pollingInterval = toDuration(intervals[1])
-	}
-	return asyncassertion.New(asyncassertion.AsyncAssertionTypeConsistently, actual, testingtsupport.BuildTestingTGomegaFailWrapper(g.t), timeoutInterval, pollingInterval, offset)
+	return g.asyncDefaults().consistently(actual, g.failWrapper, offset, intervals...)
 }
 
 // Expect is used to make assertions. See documentation for Expect.
@@ -412,6 +743,11 @@ func (g *WithT) Expect(actual interface{}, extra ...interface{}) Assertion {
 	return g.ExpectWithOffset(0, actual, extra...)
 }
 
+// ExpectReturns is used to make assertions on return values. See documentation for ExpectReturns.
+func (g *WithT) ExpectReturns(actual ...interface{}) ReturnValuesAssertion {
+	return g.ExpectReturnsWithOffset(0, actual...)
+}
+
 // Eventually is used to make asynchronous assertions. See documentation for Eventually.
 func (g *WithT) Eventually(actual interface{}, intervals ...interface{}) AsyncAssertion {
 	return g.EventuallyWithOffset(0, actual, intervals...)
@@ -422,40 +758,12 @@ func (g *WithT) Consistently(actual interface{}, intervals ...interface{}) Async
 	return g.ConsistentlyWithOffset(0, actual, intervals...)
 }
 
-func toDuration(input interface{}) time.Duration {
-	duration, ok := input.(time.Duration)
-	if ok {
-		return duration
-	}
-
-	value := reflect.ValueOf(input)
-	kind := reflect.TypeOf(input).Kind()
-
-	if reflect.Int <= kind && kind <= reflect.Int64 {
-		return time.Duration(value.Int()) * time.Second
-	} else if reflect.Uint <= kind && kind <= reflect.Uint64 {
-		return time.Duration(value.Uint()) * time.Second
-	} else if reflect.Float32 <= kind && kind <= reflect.Float64 {
-		return time.Duration(value.Float() * float64(time.Second))
-	} else if reflect.String == kind {
-		duration, err := time.ParseDuration(value.String())
-		if err != nil {
-			panic(fmt.Sprintf("%#v is not a valid parsable duration string.", input))
-		}
-		return duration
-	}
-
-	panic(fmt.Sprintf("%v is not a valid interval.  Must be time.Duration, parsable duration string or a number.", input))
-}
-
 // Gomega describes the essential Gomega DSL. This interface allows libraries
 // to abstract between the standard package-level function implementations
 // and alternatives like *WithT.
-type Gomega interface {
-	Expect(actual interface{}, extra ...interface{}) Assertion
-	Eventually(actual interface{}, intervals ...interface{}) AsyncAssertion
-	Consistently(actual interface{}, intervals ...interface{}) AsyncAssertion
-}
+//
+// Eventually and Consistently can also poll functions that take a Gomega.  See the documentation for Eventually.
+type Gomega = types.Gomega
 
 type globalFailHandlerGomega struct{}
 
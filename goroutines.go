@@ -0,0 +1,116 @@
+package gomega
+
+import (
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/onsi/gomega/types"
+)
+
+// goroutineGroup tracks the goroutines a WithT started with Go and the failures they reported
+type goroutineGroup struct {
+	// cleanup, when set, registers a function to run once the test has finished.  It is t.Cleanup for a WithT made
+	// by NewWithT.
+	cleanup func(func())
+
+	lock       sync.Mutex
+	running    sync.WaitGroup
+	registered bool
+	failures   []string
+}
+
+// goroutineFailure is panicked by the Gomega passed to a goroutine started with Go to stop it at a failed assertion
+type goroutineFailure struct {
+	message string
+}
+
+// Go runs f in a new goroutine, handing it a Gomega to make assertions with:
+//
+//    func TestServer(t *testing.T) {
+//        g := NewWithT(t)
+//        g.Go(func(g Gomega) {
+//            resp, err := http.Get(server.URL)
+//            g.Expect(err).NotTo(HaveOccurred())
+//            g.Expect(resp.StatusCode).To(Equal(http.StatusOK))
+//        })
+//        g.Wait()
+//    }
+//
+// A failed assertion stops the goroutine, not the test, and so does a panic, which is recovered.  Either way the
+// failure is reported to the WithT's test by Wait, from the test's own goroutine, along with the line Go was called
+// from.
+//
+// If the WithT was made by NewWithT, Wait is called automatically when the test finishes, so no failure in a
+// goroutine started with Go is lost or reported after the test has completed.  The test then reports the failure at
+// the end of the test rather than at a line of your own, so look for the line Go was called from in the message.
+func (g *WithT) Go(f func(g Gomega)) {
+	startedAt := "unknown location"
+	if _, file, line, ok := runtime.Caller(1); ok {
+		startedAt = fmt.Sprintf("%s:%d", file, line)
+	}
+
+	group := &g.goroutines
+	group.lock.Lock()
+	if group.cleanup != nil && !group.registered {
+		group.registered = true
+		group.cleanup(g.Wait)
+	}
+	group.running.Add(1)
+	group.lock.Unlock()
+
+	goroutineGomega := &WithT{
+		failWrapper: &types.GomegaFailWrapper{
+			Fail: func(message string, callerSkip ...int) {
+				skip := 0
+				if len(callerSkip) > 0 {
+					skip = callerSkip[0]
+				}
+				_, file, line, _ := runtime.Caller(skip + 1)
+				panic(goroutineFailure{message: fmt.Sprintf("Assertion in goroutine at %s:%d failed:\n%s", file, line, message)})
+			},
+			TWithHelper: g.failWrapper.TWithHelper,
+		},
+		defaults: g.defaults,
+	}
+
+	go func() {
+		defer group.running.Done()
+		defer func() {
+			e := recover()
+			if e == nil {
+				return
+			}
+			message := ""
+			if failure, ok := e.(goroutineFailure); ok {
+				message = failure.message
+			} else {
+				message = fmt.Sprintf("Goroutine panicked:\n%v\n\n%s", e, debug.Stack())
+			}
+			group.lock.Lock()
+			group.failures = append(group.failures, fmt.Sprintf("In goroutine started at %s:\n%s", startedAt, message))
+			group.lock.Unlock()
+		}()
+		f(goroutineGomega)
+	}()
+}
+
+// Wait blocks until every goroutine started with Go has finished, then fails the test with the failures they reported.
+// It does nothing if none of them failed.
+func (g *WithT) Wait() {
+	group := &g.goroutines
+	group.running.Wait()
+
+	group.lock.Lock()
+	failures := group.failures
+	group.failures = nil
+	group.lock.Unlock()
+
+	if len(failures) == 0 {
+		return
+	}
+	g.failWrapper.TWithHelper.Helper()
+	g.failWrapper.Fail(strings.Join(failures, "\n\n"), 1)
+}
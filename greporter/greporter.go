@@ -0,0 +1,57 @@
+/*
+Package greporter provides reporters that record the result of every Gomega assertion, for CI systems that want
+per-assertion data from suites that do not run under Ginkgo.
+
+Attach a reporter to a WithT, or to the global fail handler, and close it once the tests are done:
+
+	func TestMain(m *testing.M) {
+	    f, _ := os.Create("assertions.xml")
+	    reporter := greporter.NewJUnitReporter(f, "mypackage")
+	    gomega.AttachReporter(reporter)
+
+	    code := m.Run()
+
+	    reporter.Close()
+	    f.Close()
+	    os.Exit(code)
+	}
+
+	func TestFarmHasCow(t *testing.T) {
+	    g := gomega.NewWithT(t).AttachReporter(reporter)
+	    g.Expect(farm.HasCow()).To(BeTrue())
+	}
+
+Reporters only record failures unless IncludePasses is set.  They are safe to share between parallel tests.
+*/
+package greporter
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+
+	"github.com/onsi/gomega/types"
+)
+
+var errClosed = errors.New("greporter: reporter is closed")
+
+// matcherName returns the name of the matcher's type, e.g. "EqualMatcher"
+func matcherName(matcher types.GomegaMatcher) string {
+	if matcher == nil {
+		return ""
+	}
+	t := reflect.TypeOf(matcher)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
+
+// testName describes the assertion, e.g. "Expect Should(EqualMatcher) at farm_test.go:12"
+func testName(result types.AssertionResult) string {
+	verb := "Should"
+	if result.Negated {
+		verb = "ShouldNot"
+	}
+	return result.Assertion + " " + verb + "(" + matcherName(result.Matcher) + ") at " + filepath.Base(result.Location.String())
+}
@@ -0,0 +1,13 @@
+package greporter_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestGreporter(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Greporter Suite")
+}
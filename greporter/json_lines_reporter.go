@@ -0,0 +1,88 @@
+package greporter
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/onsi/gomega/types"
+)
+
+/*
+JSONLinesReporter writes each assertion result to an io.Writer as a single line of JSON:
+
+	{"assertion":"Eventually","passed":false,"location":"/src/farm_test.go:12","file":"/src/farm_test.go","line":12,"matcher":"EqualMatcher","negated":false,"message":"Timed out after 1.001s...","duration":1.001}
+
+duration is in seconds and only present for Eventually and Consistently.
+*/
+type JSONLinesReporter struct {
+	//IncludePasses records passing assertions as well as failing ones
+	IncludePasses bool
+
+	lock    *sync.Mutex
+	encoder *json.Encoder
+	err     error
+}
+
+type jsonLinesResult struct {
+	Assertion   string  `json:"assertion"`
+	Passed      bool    `json:"passed"`
+	Location    string  `json:"location"`
+	File        string  `json:"file"`
+	Line        int     `json:"line"`
+	Matcher     string  `json:"matcher,omitempty"`
+	Negated     bool    `json:"negated"`
+	Description string  `json:"description,omitempty"`
+	Message     string  `json:"message,omitempty"`
+	Duration    float64 `json:"duration,omitempty"`
+}
+
+/*
+NewJSONLinesReporter returns a JSONLinesReporter that writes to w
+*/
+func NewJSONLinesReporter(w io.Writer) *JSONLinesReporter {
+	return &JSONLinesReporter{
+		lock:    &sync.Mutex{},
+		encoder: json.NewEncoder(w),
+	}
+}
+
+/*
+ReportAssertion implements types.AssertionReporter
+*/
+func (r *JSONLinesReporter) ReportAssertion(result types.AssertionResult) {
+	if result.Passed && !r.IncludePasses {
+		return
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.err != nil {
+		return
+	}
+	r.err = r.encoder.Encode(jsonLinesResult{
+		Assertion:   result.Assertion,
+		Passed:      result.Passed,
+		Location:    result.Location.String(),
+		File:        result.Location.FileName,
+		Line:        result.Location.LineNumber,
+		Matcher:     matcherName(result.Matcher),
+		Negated:     result.Negated,
+		Description: result.Description,
+		Message:     result.Message,
+		Duration:    result.Duration.Seconds(),
+	})
+}
+
+/*
+Close returns the first error encountered writing results, if any.  Results reported after Close are discarded.
+*/
+func (r *JSONLinesReporter) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	err := r.err
+	if r.err == nil {
+		r.err = errClosed
+	}
+	return err
+}
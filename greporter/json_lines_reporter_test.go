@@ -0,0 +1,89 @@
+package greporter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/greporter"
+)
+
+var _ = Describe("JSONLinesReporter", func() {
+	var (
+		buffer   *bytes.Buffer
+		reporter *greporter.JSONLinesReporter
+		g        *WithT
+		failures []string
+	)
+
+	BeforeEach(func() {
+		buffer = &bytes.Buffer{}
+		reporter = greporter.NewJSONLinesReporter(buffer)
+		failures = []string{}
+		g = NewGomega(func(message string, callerSkip ...int) {
+			failures = append(failures, message)
+		}, WithDefaultEventuallyTimeout(50*time.Millisecond), WithDefaultEventuallyPollingInterval(10*time.Millisecond)).AttachReporter(reporter)
+	})
+
+	lines := func() []map[string]interface{} {
+		results := []map[string]interface{}{}
+		for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+			if line == "" {
+				continue
+			}
+			result := map[string]interface{}{}
+			Expect(json.Unmarshal([]byte(line), &result)).Should(Succeed())
+			results = append(results, result)
+		}
+		return results
+	}
+
+	It("writes a line for each failing assertion", func() {
+		_, file, line, _ := runtime.Caller(0)
+		g.Expect("foo").To(Equal("foo"))
+		g.Expect("foo").NotTo(Equal("foo"), "checking foo")
+		g.Eventually(func() int { return 0 }).Should(Equal(1))
+
+		Expect(failures).Should(HaveLen(2))
+		results := lines()
+		Expect(results).Should(HaveLen(2))
+
+		Expect(results[0]).Should(HaveKeyWithValue("assertion", "Expect"))
+		Expect(results[0]).Should(HaveKeyWithValue("passed", false))
+		Expect(results[0]).Should(HaveKeyWithValue("file", file))
+		Expect(results[0]).Should(HaveKeyWithValue("line", BeNumerically("==", line+2)))
+		Expect(results[0]).Should(HaveKeyWithValue("matcher", "EqualMatcher"))
+		Expect(results[0]).Should(HaveKeyWithValue("negated", true))
+		Expect(results[0]).Should(HaveKeyWithValue("description", "checking foo"))
+		Expect(results[0]).Should(HaveKeyWithValue("message", failures[0]))
+		Expect(results[0]).ShouldNot(HaveKey("duration"))
+
+		Expect(results[1]).Should(HaveKeyWithValue("assertion", "Eventually"))
+		Expect(results[1]).Should(HaveKeyWithValue("line", BeNumerically("==", line+3)))
+		Expect(results[1]).Should(HaveKeyWithValue("duration", BeNumerically(">=", 0.05)))
+	})
+
+	It("writes passing assertions when IncludePasses is set", func() {
+		reporter.IncludePasses = true
+		g.Expect("foo").To(Equal("foo"))
+		g.Consistently(func() int { return 1 }, 20*time.Millisecond).Should(Equal(1))
+
+		results := lines()
+		Expect(results).Should(HaveLen(2))
+		Expect(results[0]).Should(HaveKeyWithValue("passed", true))
+		Expect(results[0]).ShouldNot(HaveKey("message"))
+		Expect(results[1]).Should(HaveKeyWithValue("assertion", "Consistently"))
+		Expect(results[1]).Should(HaveKeyWithValue("passed", true))
+		Expect(results[1]).Should(HaveKeyWithValue("duration", BeNumerically(">=", 0.02)))
+	})
+
+	It("discards results reported after it is closed", func() {
+		Expect(reporter.Close()).Should(Succeed())
+		g.Expect("foo").To(Equal("bar"))
+		Expect(buffer.Len()).Should(BeZero())
+	})
+})
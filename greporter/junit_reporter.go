@@ -0,0 +1,137 @@
+package greporter
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/onsi/gomega/types"
+)
+
+/*
+JUnitReporter collects assertion results and, when closed, writes them to an io.Writer as a JUnit XML document.
+
+Each assertion is a testcase in a single testsuite.  Testcases are named after the assertion, the matcher and the
+location, e.g. "Expect Should(EqualMatcher) at farm_test.go:12", and carry file and line attributes.
+*/
+type JUnitReporter struct {
+	//IncludePasses records passing assertions as well as failing ones
+	IncludePasses bool
+
+	lock      *sync.Mutex
+	w         io.Writer
+	suiteName string
+	testCases []junitTestCase
+	closed    bool
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr"`
+	Line      int           `xml:"line,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+
+	seconds float64
+}
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+/*
+NewJUnitReporter returns a JUnitReporter that writes a testsuite with the given name to w when it is closed
+*/
+func NewJUnitReporter(w io.Writer, suiteName string) *JUnitReporter {
+	return &JUnitReporter{
+		lock:      &sync.Mutex{},
+		w:         w,
+		suiteName: suiteName,
+	}
+}
+
+/*
+ReportAssertion implements types.AssertionReporter
+*/
+func (r *JUnitReporter) ReportAssertion(result types.AssertionResult) {
+	if result.Passed && !r.IncludePasses {
+		return
+	}
+
+	testCase := junitTestCase{
+		Name:      testName(result),
+		ClassName: r.suiteName,
+		File:      result.Location.FileName,
+		Line:      result.Location.LineNumber,
+		Time:      fmt.Sprintf("%.3f", result.Duration.Seconds()),
+		seconds:   result.Duration.Seconds(),
+	}
+	if !result.Passed {
+		testCase.Failure = &junitFailure{
+			Message:  strings.SplitN(result.Message, "\n", 2)[0],
+			Type:     matcherName(result.Matcher),
+			Contents: result.Message,
+		}
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if !r.closed {
+		r.testCases = append(r.testCases, testCase)
+	}
+}
+
+/*
+Close writes the JUnit XML document.  Results reported after Close are discarded.
+*/
+func (r *JUnitReporter) Close() error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.closed {
+		return errClosed
+	}
+	r.closed = true
+
+	suite := junitTestSuite{
+		Name:      r.suiteName,
+		Tests:     len(r.testCases),
+		TestCases: r.testCases,
+	}
+	seconds := 0.0
+	for _, testCase := range r.testCases {
+		if testCase.Failure != nil {
+			suite.Failures++
+		}
+		seconds += testCase.seconds
+	}
+	suite.Time = fmt.Sprintf("%.3f", seconds)
+
+	if _, err := io.WriteString(r.w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(r.w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{TestSuites: []junitTestSuite{suite}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(r.w, "\n")
+	return err
+}
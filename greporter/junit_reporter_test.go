@@ -0,0 +1,103 @@
+package greporter_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"runtime"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/greporter"
+)
+
+type junitTestSuites struct {
+	TestSuites []struct {
+		Name      string `xml:"name,attr"`
+		Tests     int    `xml:"tests,attr"`
+		Failures  int    `xml:"failures,attr"`
+		TestCases []struct {
+			Name      string `xml:"name,attr"`
+			ClassName string `xml:"classname,attr"`
+			File      string `xml:"file,attr"`
+			Line      int    `xml:"line,attr"`
+			Failure   *struct {
+				Message  string `xml:"message,attr"`
+				Type     string `xml:"type,attr"`
+				Contents string `xml:",chardata"`
+			} `xml:"failure"`
+		} `xml:"testcase"`
+	} `xml:"testsuite"`
+}
+
+var _ = Describe("JUnitReporter", func() {
+	var (
+		buffer   *bytes.Buffer
+		reporter *greporter.JUnitReporter
+		g        *WithT
+		failures []string
+	)
+
+	BeforeEach(func() {
+		buffer = &bytes.Buffer{}
+		reporter = greporter.NewJUnitReporter(buffer, "my suite")
+		failures = []string{}
+		g = NewGomega(func(message string, callerSkip ...int) {
+			failures = append(failures, message)
+		}).AttachReporter(reporter)
+	})
+
+	decode := func() junitTestSuites {
+		suites := junitTestSuites{}
+		Expect(xml.Unmarshal(buffer.Bytes(), &suites)).Should(Succeed())
+		Expect(suites.TestSuites).Should(HaveLen(1))
+		return suites
+	}
+
+	It("writes nothing until it is closed", func() {
+		g.Expect("foo").To(Equal("bar"))
+		Expect(buffer.Len()).Should(BeZero())
+		Expect(reporter.Close()).Should(Succeed())
+		Expect(buffer.String()).Should(HavePrefix(xml.Header))
+	})
+
+	It("writes a testcase for each failing assertion", func() {
+		_, file, line, _ := runtime.Caller(0)
+		g.Expect("foo").To(Equal("foo"))
+		g.Expect("foo").To(Equal("bar"))
+		Expect(reporter.Close()).Should(Succeed())
+
+		suite := decode().TestSuites[0]
+		Expect(suite.Name).Should(Equal("my suite"))
+		Expect(suite.Tests).Should(Equal(1))
+		Expect(suite.Failures).Should(Equal(1))
+
+		testCase := suite.TestCases[0]
+		Expect(testCase.Name).Should(Equal(fmt.Sprintf("Expect Should(EqualMatcher) at %s:%d", filepath.Base(file), line+2)))
+		Expect(testCase.ClassName).Should(Equal("my suite"))
+		Expect(testCase.File).Should(Equal(file))
+		Expect(testCase.Line).Should(Equal(line + 2))
+		Expect(testCase.Failure.Message).Should(Equal("Expected"))
+		Expect(testCase.Failure.Type).Should(Equal("EqualMatcher"))
+		Expect(testCase.Failure.Contents).Should(Equal(failures[0]))
+	})
+
+	It("writes passing assertions when IncludePasses is set", func() {
+		reporter.IncludePasses = true
+		g.Expect("foo").To(Equal("foo"))
+		g.Expect("foo").NotTo(Equal("foo"))
+		Expect(reporter.Close()).Should(Succeed())
+
+		suite := decode().TestSuites[0]
+		Expect(suite.Tests).Should(Equal(2))
+		Expect(suite.Failures).Should(Equal(1))
+		Expect(suite.TestCases[0].Failure).Should(BeNil())
+		Expect(suite.TestCases[1].Name).Should(HavePrefix("Expect ShouldNot(EqualMatcher)"))
+	})
+
+	It("can only be closed once", func() {
+		Expect(reporter.Close()).Should(Succeed())
+		Expect(reporter.Close()).ShouldNot(Succeed())
+	})
+})
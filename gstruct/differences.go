@@ -0,0 +1,34 @@
+package gstruct
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	errorsutil "github.com/onsi/gomega/gstruct/errors"
+	"github.com/onsi/gomega/types"
+)
+
+//A matcher, like Equal, that can list the paths at which an actual value differs from the value it expected.
+type differencesMatcher interface {
+	Differences(actual interface{}) []format.Difference
+}
+
+//failureFor returns the error describing why matcher did not match actual.  Nesting matchers report their nested
+//failures, and matchers that can list differences report the paths at which actual differs from what they expected,
+//relative to actual.
+func failureFor(matcher types.GomegaMatcher, actual interface{}) error {
+	if nesting, ok := matcher.(errorsutil.NestingMatcher); ok {
+		return errorsutil.AggregateError(nesting.Failures())
+	}
+	if diffing, ok := matcher.(differencesMatcher); ok && format.UseStructuralDiff {
+		if differences := diffing.Differences(actual); len(differences) > 0 && differences[0].Path != "" {
+			lines := make([]string, len(differences))
+			for i, difference := range differences {
+				lines[i] = difference.String()
+			}
+			return errors.New(strings.Join(lines, "\n"))
+		}
+	}
+	return errors.New(matcher.FailureMessage(actual))
+}
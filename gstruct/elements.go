@@ -3,7 +3,6 @@
 package gstruct
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 	"runtime/debug"
@@ -199,11 +198,7 @@ func (m *ElementsMatcher) matchElements(actual interface{}) (errs []error) {
 		}
 
 		if err == nil {
-			if nesting, ok := matcher.(errorsutil.NestingMatcher); ok {
-				err = errorsutil.AggregateError(nesting.Failures())
-			} else {
-				err = errors.New(matcher.FailureMessage(element))
-			}
+			err = failureFor(matcher, element)
 		}
 		errs = append(errs, errorsutil.Nest(fmt.Sprintf("[%s]", id), err))
 	}
@@ -3,7 +3,6 @@
 package gstruct
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 	"runtime/debug"
@@ -126,10 +125,7 @@ func (m *FieldsMatcher) matchFields(actual interface{}) (errs []error) {
 			if err != nil {
 				return err
 			} else if !match {
-				if nesting, ok := matcher.(errorsutil.NestingMatcher); ok {
-					return errorsutil.AggregateError(nesting.Failures())
-				}
-				return errors.New(matcher.FailureMessage(field))
+				return failureFor(matcher, field)
 			}
 			return nil
 		}()
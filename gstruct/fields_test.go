@@ -98,4 +98,20 @@ var _ = Describe("Struct", func() {
 			".C:\n	unexpected field C: {A:b C:c}",
 		))
 	})
+
+	It("should list the paths at which a field differs from the value it should equal", func() {
+		type inner struct {
+			Names []string
+			Count int
+		}
+		m := MatchAllFields(Fields{
+			"Inner": Equal(inner{Names: []string{"a", "b"}, Count: 1}),
+		})
+
+		actual := struct{ Inner inner }{Inner: inner{Names: []string{"a", "c"}, Count: 1}}
+		m.Match(actual)
+		Expect(m.FailureMessage(actual)).Should(ContainSubstring(
+			".Inner:\n	.Names[1]: \"c\" != \"b\"\n}",
+		))
+	})
 })
@@ -3,7 +3,6 @@
 package gstruct
 
 import (
-	"errors"
 	"fmt"
 	"reflect"
 	"runtime/debug"
@@ -87,10 +86,7 @@ func (m *KeysMatcher) matchKeys(actual interface{}) (errs []error) {
 			}
 
 			if !match {
-				if nesting, ok := matcher.(errorsutil.NestingMatcher); ok {
-					return errorsutil.AggregateError(nesting.Failures())
-				}
-				return errors.New(matcher.FailureMessage(valInterface))
+				return failureFor(matcher, valInterface)
 			}
 			return nil
 		}()
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 
+	"github.com/onsi/gomega/internal/failure"
 	"github.com/onsi/gomega/types"
 )
 
@@ -63,24 +64,30 @@ func (assertion *Assertion) buildDescription(optionalDescription ...interface{})
 func (assertion *Assertion) match(matcher types.GomegaMatcher, desiredMatch bool, optionalDescription ...interface{}) bool {
 	matches, err := matcher.Match(assertion.actualInput)
 	assertion.failWrapper.TWithHelper.Helper()
+	var message string
 	if err != nil {
-		description := assertion.buildDescription(optionalDescription...)
-		assertion.failWrapper.Fail(description+err.Error(), 2+assertion.offset)
-		return false
-	}
-	if matches != desiredMatch {
-		var message string
+		message = err.Error()
+	} else if matches != desiredMatch {
 		if desiredMatch {
 			message = matcher.FailureMessage(assertion.actualInput)
 		} else {
 			message = matcher.NegatedFailureMessage(assertion.actualInput)
 		}
-		description := assertion.buildDescription(optionalDescription...)
-		assertion.failWrapper.Fail(description+message, 2+assertion.offset)
-		return false
+	} else {
+		failure.ReportPass(assertion.failWrapper, "Expect", matcher, !desiredMatch, 0, 2+assertion.offset)
+		return true
 	}
 
-	return true
+	description := assertion.buildDescription(optionalDescription...)
+	f := failure.New(description+message, description, matcher, assertion.actualInput, !desiredMatch, 2+assertion.offset)
+	failure.ReportFailure(assertion.failWrapper, "Expect", f, 0)
+	defer failure.MarkHandlerPanic()
+	if handler := assertion.failWrapper.FailureHandler; handler != nil {
+		handler(f, 2+assertion.offset)
+	} else {
+		assertion.failWrapper.Fail(f.Message, 2+assertion.offset)
+	}
+	return false
 }
 
 func (assertion *Assertion) vetExtras(optionalDescription ...interface{}) bool {
@@ -91,7 +98,14 @@ func (assertion *Assertion) vetExtras(optionalDescription ...interface{}) bool {
 
 	description := assertion.buildDescription(optionalDescription...)
 	assertion.failWrapper.TWithHelper.Helper()
-	assertion.failWrapper.Fail(description+message, 2+assertion.offset)
+	f := failure.New(description+message, description, nil, assertion.actualInput, false, 2+assertion.offset)
+	failure.ReportFailure(assertion.failWrapper, "Expect", f, 0)
+	defer failure.MarkHandlerPanic()
+	if handler := assertion.failWrapper.FailureHandler; handler != nil {
+		handler(f, 2+assertion.offset)
+	} else {
+		assertion.failWrapper.Fail(f.Message, 2+assertion.offset)
+	}
 	return false
 }
 
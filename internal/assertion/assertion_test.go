@@ -2,6 +2,7 @@ package assertion_test
 
 import (
 	"errors"
+	"runtime"
 
 	"github.com/onsi/gomega/internal/testingtsupport"
 
@@ -260,6 +261,129 @@ var _ = Describe("Assertion", func() {
 		})
 	})
 
+	Context("when the fail wrapper has a structured failure handler", func() {
+		var (
+			failures              []types.Failure
+			structuredFailWrapper *types.GomegaFailWrapper
+		)
+
+		BeforeEach(func() {
+			failures = []types.Failure{}
+			structuredFailWrapper = &types.GomegaFailWrapper{
+				Fail: func(message string, callerSkip ...int) {
+					Fail("the string fail handler should not be called")
+				},
+				FailureHandler: func(failure types.Failure, callerSkip ...int) {
+					failures = append(failures, failure)
+					if len(callerSkip) == 1 {
+						failureCallerSkip = callerSkip[0]
+					}
+				},
+				TWithHelper: testingtsupport.EmptyTWithHelper{},
+			}
+			a = assertion.New(input, structuredFailWrapper, 0)
+		})
+
+		It("should report the failure's raw values", func() {
+			_, file, line, _ := runtime.Caller(0)
+			Expect(a.ShouldNot(Equal(input), "a %s", "description")).Should(BeFalse())
+
+			Expect(failures).Should(HaveLen(1))
+			Expect(failures[0].Message).Should(HavePrefix("a description\nExpected\n"))
+			Expect(failures[0].Description).Should(Equal("a description"))
+			Expect(failures[0].Actual).Should(Equal(input))
+			Expect(failures[0].Expected).Should(Equal(input))
+			Expect(failures[0].Matcher).Should(Equal(Equal(input)))
+			Expect(failures[0].Negated).Should(BeTrue())
+			Expect(failures[0].Location).Should(Equal(types.FailureLocation{FileName: file, LineNumber: line + 1}))
+			Expect(failureCallerSkip).Should(Equal(2))
+		})
+
+		It("should leave Expected empty when the matcher has no Expected field", func() {
+			matcher.MatchesToReturn = false
+			a.Should(matcher)
+
+			Expect(failures).Should(HaveLen(1))
+			Expect(failures[0].Message).Should(Equal("positive: The thing I'm testing"))
+			Expect(failures[0].Matcher).Should(Equal(matcher))
+			Expect(failures[0].Expected).Should(BeNil())
+		})
+
+		It("should report errors and non-zero extra arguments", func() {
+			matcher.ErrToReturn = errors.New("kaboom")
+			a.Should(matcher)
+
+			a = assertion.New(input, structuredFailWrapper, 0, errors.New("foo"))
+			a.Should(matcher)
+
+			Expect(failures).Should(HaveLen(2))
+			Expect(failures[0].Message).Should(Equal("kaboom"))
+			Expect(failures[0].Matcher).Should(Equal(matcher))
+			Expect(failures[1].Message).Should(ContainSubstring("foo"))
+			Expect(failures[1].Matcher).Should(BeNil())
+			Expect(failures[1].Actual).Should(Equal(input))
+		})
+	})
+
+	Context("when making assertions on return values", func() {
+		returns := func() (int, string, error) {
+			return 3, "xyz", nil
+		}
+
+		It("should match each return value against the matcher in its position", func() {
+			ra := assertion.NewReturnValues([]interface{}{3, "xyz", nil}, fakeFailWrapper, 1)
+			Expect(ra.To(Equal(3), HavePrefix("x"), Succeed())).Should(BeTrue())
+			Expect(ra.Should(Equal(3), HavePrefix("x"), BeNil())).Should(BeTrue())
+			Expect(failureMessage).Should(BeZero())
+		})
+
+		It("should call the failure callback naming the return value that did not match", func() {
+			ra := assertion.NewReturnValues([]interface{}{3, "xyz", nil}, fakeFailWrapper, 1)
+			Expect(ra.To(Equal(3), HavePrefix("y"), Succeed())).Should(BeFalse())
+			Expect(failureMessage).Should(HavePrefix("Return value at index 1 did not match:\n"))
+			Expect(failureCallerSkip).Should(Equal(3))
+		})
+
+		It("should support negated assertions", func() {
+			ra := assertion.NewReturnValues([]interface{}{3, "xyz", nil}, fakeFailWrapper, 1)
+			Expect(ra.ShouldNot(Equal(3), HavePrefix("y"), Succeed())).Should(BeTrue())
+			Expect(ra.ToNot(Equal(4), HavePrefix("x"), Succeed())).Should(BeTrue())
+			Expect(failureMessage).Should(BeZero())
+
+			Expect(ra.NotTo(Equal(3), HavePrefix("x"), Succeed())).Should(BeFalse())
+			Expect(failureMessage).Should(Equal(`Expected
+    <[]interface {} | len:3, cap:3>: [<int>3, <string>"xyz", nil]
+not to have return values matching:
+    Return value at index 0:
+        Expected
+            <int>: 3
+        not to equal
+            <int>: 3
+    Return value at index 1:
+        Expected
+            <string>: xyz
+        not to have prefix
+            <string>: x
+    Return value at index 2:
+        Expected failure, but got no error.`))
+			Expect(failureCallerSkip).Should(Equal(3))
+		})
+
+		It("should include the description given with WithDescription", func() {
+			ra := assertion.NewReturnValues([]interface{}{3, "xyz", nil}, fakeFailWrapper, 1)
+			Expect(ra.WithDescription("record %d", 7).To(Equal(4), HavePrefix("x"), Succeed())).Should(BeFalse())
+			Expect(failureMessage).Should(HavePrefix("record 7\nReturn value at index 0 did not match:\n"))
+		})
+
+		It("should work with ExpectReturns", func() {
+			Expect(ExpectReturns(returns()).To(Equal(3), HavePrefix("x"), Succeed())).Should(BeTrue())
+			failures := InterceptGomegaFailures(func() {
+				ExpectReturns(returns()).To(Equal(3), HavePrefix("x"), HaveOccurred())
+			})
+			Expect(failures).Should(ConsistOf(HavePrefix("Return value at index 2 did not match:\n")))
+		})
+	})
+
 	Context("Making an assertion without a registered fail handler", func() {
 		It("should panic", func() {
 			defer func() {
@@ -0,0 +1,47 @@
+package assertion
+
+import (
+	"github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+type ReturnValuesAssertion struct {
+	assertion           *Assertion
+	optionalDescription []interface{}
+}
+
+func NewReturnValues(values []interface{}, failWrapper *types.GomegaFailWrapper, offset int) *ReturnValuesAssertion {
+	return &ReturnValuesAssertion{
+		assertion: New(values, failWrapper, offset),
+	}
+}
+
+func (assertion *ReturnValuesAssertion) WithDescription(optionalDescription ...interface{}) types.ReturnValuesAssertion {
+	assertion.optionalDescription = optionalDescription
+	return assertion
+}
+
+func (assertion *ReturnValuesAssertion) Should(ms ...types.GomegaMatcher) bool {
+	assertion.assertion.failWrapper.TWithHelper.Helper()
+	return assertion.assertion.match(&matchers.ReturnValuesMatcher{Matchers: ms}, true, assertion.optionalDescription...)
+}
+
+func (assertion *ReturnValuesAssertion) ShouldNot(ms ...types.GomegaMatcher) bool {
+	assertion.assertion.failWrapper.TWithHelper.Helper()
+	return assertion.assertion.match(&matchers.ReturnValuesMatcher{Matchers: ms}, false, assertion.optionalDescription...)
+}
+
+func (assertion *ReturnValuesAssertion) To(ms ...types.GomegaMatcher) bool {
+	assertion.assertion.failWrapper.TWithHelper.Helper()
+	return assertion.assertion.match(&matchers.ReturnValuesMatcher{Matchers: ms}, true, assertion.optionalDescription...)
+}
+
+func (assertion *ReturnValuesAssertion) ToNot(ms ...types.GomegaMatcher) bool {
+	assertion.assertion.failWrapper.TWithHelper.Helper()
+	return assertion.assertion.match(&matchers.ReturnValuesMatcher{Matchers: ms}, false, assertion.optionalDescription...)
+}
+
+func (assertion *ReturnValuesAssertion) NotTo(ms ...types.GomegaMatcher) bool {
+	assertion.assertion.failWrapper.TWithHelper.Helper()
+	return assertion.assertion.match(&matchers.ReturnValuesMatcher{Matchers: ms}, false, assertion.optionalDescription...)
+}
@@ -3,12 +3,19 @@
 package asyncassertion
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"reflect"
+	"runtime/debug"
+	"strings"
 	"time"
 
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/internal/failure"
 	"github.com/onsi/gomega/internal/oraclematcher"
+	"github.com/onsi/gomega/internal/returnvaluesmatcher"
+	"github.com/onsi/gomega/matchers"
 	"github.com/onsi/gomega/types"
 )
 
@@ -20,30 +27,190 @@ const (
 )
 
 type AsyncAssertion struct {
-	asyncType       AsyncAssertionType
-	actualInput     interface{}
-	timeoutInterval time.Duration
-	pollingInterval time.Duration
-	failWrapper     *types.GomegaFailWrapper
-	offset          int
+	asyncType          AsyncAssertionType
+	actualInput        interface{}
+	timeoutInterval    time.Duration
+	pollingInterval    time.Duration
+	failWrapper        *types.GomegaFailWrapper
+	offset             int
+	ctx                context.Context
+	argsErrors         []string
+	recordTimeline     bool
+	mustPassRepeatedly int
+	pollingStrategy    types.PollingStrategy
+	clock              types.Clock
+	failOnPanic        bool
 }
 
+var gomegaType = reflect.TypeOf((*types.Gomega)(nil)).Elem()
+
 func New(asyncType AsyncAssertionType, actualInput interface{}, failWrapper *types.GomegaFailWrapper, timeoutInterval time.Duration, pollingInterval time.Duration, offset int) *AsyncAssertion {
 	actualType := reflect.TypeOf(actualInput)
 	if actualType.Kind() == reflect.Func {
-		if actualType.NumIn() != 0 || actualType.NumOut() == 0 {
-			panic("Expected a function with no arguments and one or more return values.")
+		takesNothing := actualType.NumIn() == 0 && actualType.NumOut() > 0
+		takesGomega := actualType.NumIn() == 1 && actualType.In(0) == gomegaType
+		if !takesNothing && !takesGomega {
+			panic("Expected a function with no arguments and one or more return values, or a function that takes a Gomega and returns zero or more values.")
 		}
 	}
 
 	return &AsyncAssertion{
-		asyncType:       asyncType,
-		actualInput:     actualInput,
-		failWrapper:     failWrapper,
-		timeoutInterval: timeoutInterval,
-		pollingInterval: pollingInterval,
-		offset:          offset,
+		asyncType:          asyncType,
+		actualInput:        actualInput,
+		failWrapper:        failWrapper,
+		timeoutInterval:    timeoutInterval,
+		pollingInterval:    pollingInterval,
+		offset:             offset,
+		mustPassRepeatedly: 1,
+		clock:              realClock{},
+	}
+}
+
+// NewWithIntervals builds an AsyncAssertion from the optional intervals passed to Eventually and Consistently.
+// The first two durations override timeoutInterval and pollingInterval; a context.Context may appear anywhere among them.
+func NewWithIntervals(asyncType AsyncAssertionType, actualInput interface{}, failWrapper *types.GomegaFailWrapper, timeoutInterval time.Duration, pollingInterval time.Duration, offset int, intervals ...interface{}) *AsyncAssertion {
+	var ctx context.Context
+	durations := []interface{}{}
+	for _, interval := range intervals {
+		if c, ok := interval.(context.Context); ok {
+			ctx = c
+			continue
+		}
+		durations = append(durations, interval)
+	}
+	argsErrors := []string{}
+	if len(durations) > 0 {
+		duration, err := ToDuration(durations[0])
+		if err == nil {
+			timeoutInterval = duration
+		} else {
+			argsErrors = append(argsErrors, "timeout: "+err.Error())
+		}
+	}
+	if len(durations) > 1 {
+		duration, err := ToDuration(durations[1])
+		if err == nil {
+			pollingInterval = duration
+		} else {
+			argsErrors = append(argsErrors, "polling interval: "+err.Error())
+		}
+	}
+	assertion := New(asyncType, actualInput, failWrapper, timeoutInterval, pollingInterval, offset)
+	assertion.ctx = ctx
+	assertion.argsErrors = argsErrors
+	return assertion
+}
+
+// ToDuration converts an interval passed to Eventually or Consistently into a time.Duration.
+// Numbers are interpreted as seconds.
+func ToDuration(input interface{}) (time.Duration, error) {
+	duration, ok := input.(time.Duration)
+	if ok {
+		return duration, nil
+	}
+
+	if input == nil {
+		return 0, errors.New("nil is not a valid interval.  Must be time.Duration, parsable duration string or a number.")
+	}
+
+	value := reflect.ValueOf(input)
+	kind := reflect.TypeOf(input).Kind()
+
+	if reflect.Int <= kind && kind <= reflect.Int64 {
+		return time.Duration(value.Int()) * time.Second, nil
+	} else if reflect.Uint <= kind && kind <= reflect.Uint64 {
+		return time.Duration(value.Uint()) * time.Second, nil
+	} else if reflect.Float32 <= kind && kind <= reflect.Float64 {
+		return time.Duration(value.Float() * float64(time.Second)), nil
+	} else if reflect.String == kind {
+		duration, err := time.ParseDuration(value.String())
+		if err != nil {
+			return 0, fmt.Errorf("%#v is not a valid parsable duration string.", input)
+		}
+		return duration, nil
+	}
+
+	return 0, fmt.Errorf("%v is not a valid interval.  Must be time.Duration, parsable duration string or a number.", input)
+}
+
+// WithContext makes the assertion stop polling, and fail, as soon as ctx is done.
+func (assertion *AsyncAssertion) WithContext(ctx context.Context) types.AsyncAssertion {
+	assertion.ctx = ctx
+	return assertion
+}
+
+// WithTimeout sets how long Eventually polls for, or how long Consistently must pass for.
+func (assertion *AsyncAssertion) WithTimeout(interval time.Duration) types.AsyncAssertion {
+	if interval < 0 {
+		assertion.argsErrors = append(assertion.argsErrors, fmt.Sprintf("WithTimeout: the timeout must not be negative, got %s", interval))
+		return assertion
+	}
+	assertion.timeoutInterval = interval
+	return assertion
+}
+
+// WithPolling sets how long to wait between polls.
+func (assertion *AsyncAssertion) WithPolling(interval time.Duration) types.AsyncAssertion {
+	if interval <= 0 {
+		assertion.argsErrors = append(assertion.argsErrors, fmt.Sprintf("WithPolling: the polling interval must be positive, got %s", interval))
+		return assertion
+	}
+	assertion.pollingInterval = interval
+	return assertion
+}
+
+// Within is an alias for WithTimeout.
+func (assertion *AsyncAssertion) Within(timeout time.Duration) types.AsyncAssertion {
+	return assertion.WithTimeout(timeout)
+}
+
+// ProbeEvery is an alias for WithPolling.
+func (assertion *AsyncAssertion) ProbeEvery(interval time.Duration) types.AsyncAssertion {
+	return assertion.WithPolling(interval)
+}
+
+// WithTimeline turns on (or off) recording of every poll.  When on, failure messages end with a timeline of
+// the values seen and whether each one matched.
+func (assertion *AsyncAssertion) WithTimeline(enabled bool) types.AsyncAssertion {
+	assertion.recordTimeline = enabled
+	return assertion
+}
+
+// MustPassRepeatedly makes Eventually pass only once count consecutive polls have passed.
+// Any poll that does not pass starts the count over.
+func (assertion *AsyncAssertion) MustPassRepeatedly(count int) types.AsyncAssertion {
+	if assertion.asyncType == AsyncAssertionTypeConsistently {
+		assertion.argsErrors = append(assertion.argsErrors, "MustPassRepeatedly: only Eventually supports MustPassRepeatedly")
+		return assertion
+	}
+	if count < 1 {
+		assertion.argsErrors = append(assertion.argsErrors, fmt.Sprintf("MustPassRepeatedly: the count must be at least 1, got %d", count))
+		return assertion
 	}
+	assertion.mustPassRepeatedly = count
+	return assertion
+}
+
+// WithPollingStrategy sets how the time between polls evolves.  A nil strategy polls at a fixed interval.
+func (assertion *AsyncAssertion) WithPollingStrategy(strategy types.PollingStrategy) types.AsyncAssertion {
+	assertion.pollingStrategy = strategy
+	return assertion
+}
+
+// WithClock sets the clock used to time the assertion.  A nil clock uses the real time.
+func (assertion *AsyncAssertion) WithClock(clock types.Clock) types.AsyncAssertion {
+	if clock == nil {
+		clock = realClock{}
+	}
+	assertion.clock = clock
+	return assertion
+}
+
+// WithFailOnPanic makes a panic in the polled function fail the assertion immediately.  By default a panic counts as
+// a poll that did not match, and Eventually keeps polling.
+func (assertion *AsyncAssertion) WithFailOnPanic(enabled bool) types.AsyncAssertion {
+	assertion.failOnPanic = enabled
+	return assertion
 }
 
 func (assertion *AsyncAssertion) Should(matcher types.GomegaMatcher, optionalDescription ...interface{}) bool {
@@ -70,28 +237,77 @@ func (assertion *AsyncAssertion) buildDescription(optionalDescription ...interfa
 
 func (assertion *AsyncAssertion) actualInputIsAFunction() bool {
 	actualType := reflect.TypeOf(assertion.actualInput)
-	return actualType.Kind() == reflect.Func && actualType.NumIn() == 0 && actualType.NumOut() > 0
+	return actualType.Kind() == reflect.Func
 }
 
-func (assertion *AsyncAssertion) pollActual() (interface{}, error) {
-	if assertion.actualInputIsAFunction() {
-		values := reflect.ValueOf(assertion.actualInput).Call([]reflect.Value{})
+func (assertion *AsyncAssertion) actualInputTakesGomega() bool {
+	actualType := reflect.TypeOf(assertion.actualInput)
+	return actualType.Kind() == reflect.Func && actualType.NumIn() == 1
+}
 
-		extras := []interface{}{}
-		for _, value := range values[1:] {
-			extras = append(extras, value.Interface())
+// pollActual calls the actual function, if it is one, and returns the value to match.  That is its first return
+// value, unless the matcher is a ReturnValues matcher which is handed all of them.
+func (assertion *AsyncAssertion) pollActual(matcher types.GomegaMatcher) (value interface{}, err error) {
+	if !assertion.actualInputIsAFunction() {
+		return assertion.actualInput, nil
+	}
+
+	defer func() {
+		if e := recover(); e != nil {
+			if failure, ok := e.(pollingFailure); ok {
+				value, err = nil, errors.New(failure.message)
+				return
+			}
+			if asStopTryingError(e) != nil {
+				value, err = nil, e.(error)
+				return
+			}
+			if raisedByFailHandler(e) {
+				panic(e)
+			}
+			value, err = nil, &polledPanic{value: e, stack: debug.Stack()}
 		}
+	}()
+
+	args := []reflect.Value{}
+	if assertion.actualInputTakesGomega() {
+		args = append(args, reflect.ValueOf(newPollingGomega(assertion)))
+	}
+
+	values := reflect.ValueOf(assertion.actualInput).Call(args)
+	if len(values) == 0 {
+		return nil, nil
+	}
 
-		success, message := vetExtras(extras)
+	for i, value := range values {
+		if asStopTryingError(value.Interface()) != nil {
+			if i == 0 {
+				return nil, value.Interface().(error)
+			}
+			return values[0].Interface(), value.Interface().(error)
+		}
+	}
 
-		if !success {
-			return nil, errors.New(message)
+	if returnvaluesmatcher.MatchesReturnValues(matcher) {
+		returnValues := []interface{}{}
+		for _, value := range values {
+			returnValues = append(returnValues, value.Interface())
 		}
+		return returnValues, nil
+	}
+
+	extras := []interface{}{}
+	for _, value := range values[1:] {
+		extras = append(extras, value.Interface())
+	}
+
+	success, message := vetExtras(extras)
 
-		return values[0].Interface(), nil
+	if !success {
+		return nil, errors.New(message)
 	}
 
-	return assertion.actualInput, nil
+	return values[0].Interface(), nil
 }
 
 func (assertion *AsyncAssertion) matcherMayChange(matcher types.GomegaMatcher, value interface{}) bool {
@@ -102,26 +318,164 @@ func (assertion *AsyncAssertion) matcherMayChange(matcher types.GomegaMatcher, v
 	return oraclematcher.MatchMayChangeInTheFuture(matcher, value)
 }
 
+// receivesFromChannel is true when the assertion should receive from the actual channel itself and
+// hand each value to a Receive matcher, rather than letting the matcher poll the channel.
+func (assertion *AsyncAssertion) receivesFromChannel(matcher types.GomegaMatcher) bool {
+	if _, ok := matcher.(*matchers.ReceiveMatcher); !ok {
+		return false
+	}
+	actualType := reflect.TypeOf(assertion.actualInput)
+	return actualType.Kind() == reflect.Chan && actualType.ChanDir() != reflect.SendDir
+}
+
 func (assertion *AsyncAssertion) match(matcher types.GomegaMatcher, desiredMatch bool, optionalDescription ...interface{}) bool {
-	timer := time.Now()
-	timeout := time.After(assertion.timeoutInterval)
+	assertion.failWrapper.TWithHelper.Helper()
+	if len(assertion.argsErrors) > 0 {
+		description := assertion.buildDescription(optionalDescription...)
+		message := fmt.Sprintf("%sInvalid arguments passed to %s:\n\t%s", description, assertion.asyncTypeName(), strings.Join(assertion.argsErrors, "\n\t"))
+		f := failure.New(message, description, matcher, nil, !desiredMatch, 2+assertion.offset)
+		failure.ReportFailure(assertion.failWrapper, assertion.asyncTypeName(), f, 0)
+		defer failure.MarkHandlerPanic()
+		if handler := assertion.failWrapper.FailureHandler; handler != nil {
+			handler(f, 2+assertion.offset)
+		} else {
+			assertion.failWrapper.Fail(message, 2+assertion.offset)
+		}
+		return false
+	}
+
+	timer := assertion.clock.Now()
+	timeout := assertion.clock.After(assertion.timeoutInterval)
+	var contextDone <-chan struct{}
+	if assertion.ctx != nil {
+		contextDone = assertion.ctx.Done()
+	}
 
 	var matches bool
 	var err error
+	var value interface{}
+	var stopTrying *StopTryingError
+	var panicked *polledPanic
 	mayChange := true
-	value, err := assertion.pollActual()
-	if err == nil {
+	pollAndMatch := func() {
+		polledValue, polledErr := assertion.pollActual(matcher)
+		if errors.As(polledErr, &stopTrying) {
+			// keep reporting the last value we saw if the function stopped without returning one
+			if polledValue != nil {
+				value = polledValue
+			}
+			err = polledErr
+			return
+		}
+		value, err = polledValue, polledErr
+		if !errors.As(err, &panicked) {
+			panicked = nil
+		}
+		if err == nil {
+			mayChange = assertion.matcherMayChange(matcher, value)
+			matches, err = matcher.Match(value)
+		}
+	}
+
+	var polls *timeline
+	if assertion.recordTimeline {
+		polls = &timeline{}
+	}
+	recordPoll := func(summary string) {
+		if polls == nil {
+			return
+		}
+		result := "no match"
+		if stopTrying != nil {
+			result = "told to stop trying"
+		} else if panicked != nil {
+			result = "panicked: " + truncateSummary(fmt.Sprint(panicked.value))
+		} else if err != nil {
+			result = "error: " + truncateSummary(err.Error())
+		} else if matches == desiredMatch {
+			result = "match"
+		}
+		polls.record(assertion.clock.Now().Sub(timer), summary, result)
+	}
+
+	var receiver *channelReceiver
+	if assertion.receivesFromChannel(matcher) {
+		receiver = newChannelReceiver(assertion.actualInput)
+	}
+	receiveAndMatch := func(standIn interface{}) {
+		value = assertion.actualInput
+		matches, err = matcher.Match(standIn)
 		mayChange = assertion.matcherMayChange(matcher, value)
-		matches, err = matcher.Match(value)
+		if receiver.didReceive {
+			recordPoll("received " + summarizeValue(receiver.lastReceived.Interface()))
+		} else {
+			recordPoll("nothing received")
+		}
+	}
+	pollAndRecord := func() {
+		pollAndMatch()
+		if err != nil && value == nil {
+			recordPoll("no value")
+		} else {
+			recordPoll(summarizeValue(value))
+		}
+	}
+
+	if receiver != nil {
+		receiveAndMatch(receiver.receiveNow())
+	} else {
+		pollAndRecord()
+	}
+
+	const (
+		polled = iota
+		timedOut
+		cancelled
+	)
+	pollCount := 1
+	waitAndPoll := func() int {
+		pollingTick := assertion.clock.After(pollingIntervalFor(assertion.pollingStrategy, assertion.pollingInterval, pollCount))
+		pollCount++
+		if receiver != nil {
+			switch chosen, standIn := receiver.receive(pollingTick, timeout, contextDone); chosen {
+			case 0:
+				receiveAndMatch(receiver.receiveNow())
+			case 1:
+				return timedOut
+			case 2:
+				return cancelled
+			default:
+				receiveAndMatch(standIn)
+			}
+			return polled
+		}
+
+		select {
+		case <-pollingTick:
+			pollAndRecord()
+			return polled
+		case <-timeout:
+			return timedOut
+		case <-contextDone:
+			return cancelled
+		}
 	}
 
 	assertion.failWrapper.TWithHelper.Helper()
 
+	longestPassingRun := 0
 	fail := func(preamble string) {
 		errMsg := ""
 		message := ""
-		if err != nil {
+		if stopTrying != nil {
 			errMsg = "Error: " + err.Error()
+			if value != nil {
+				errMsg += "\nLast observed value:\n" + format.Object(value, 1)
+			}
+		} else if err != nil {
+			errMsg = "Error: " + err.Error()
+		} else if matches == desiredMatch {
+			message = "The last poll passed.  Last observed value:\n" + format.Object(value, 1)
 		} else {
 			if desiredMatch {
 				message = matcher.FailureMessage(value)
@@ -129,15 +483,57 @@ func (assertion *AsyncAssertion) match(matcher types.GomegaMatcher, desiredMatch
 				message = matcher.NegatedFailureMessage(value)
 			}
 		}
+		addenda := ""
+		if assertion.mustPassRepeatedly > 1 {
+			addenda += fmt.Sprintf("\nMustPassRepeatedly(%d): at most %d consecutive polls passed", assertion.mustPassRepeatedly, longestPassingRun)
+		}
+		if receiver != nil {
+			addenda += "\n" + receiver.summary()
+		}
+		if polls != nil {
+			addenda += "\n" + polls.String()
+		}
 		assertion.failWrapper.TWithHelper.Helper()
 		description := assertion.buildDescription(optionalDescription...)
-		assertion.failWrapper.Fail(fmt.Sprintf("%s after %.3fs.\n%s%s%s", preamble, time.Since(timer).Seconds(), description, message, errMsg), 3+assertion.offset)
+		duration := assertion.clock.Now().Sub(timer)
+		fullMessage := fmt.Sprintf("%s after %.3fs.\n%s%s%s%s", preamble, duration.Seconds(), description, message, errMsg, addenda)
+		f := failure.New(fullMessage, description, matcher, value, !desiredMatch, 3+assertion.offset)
+		failure.ReportFailure(assertion.failWrapper, assertion.asyncTypeName(), f, duration)
+		defer failure.MarkHandlerPanic()
+		if handler := assertion.failWrapper.FailureHandler; handler != nil {
+			handler(f, 3+assertion.offset)
+		} else {
+			assertion.failWrapper.Fail(fullMessage, 3+assertion.offset)
+		}
+	}
+	pass := func() {
+		failure.ReportPass(assertion.failWrapper, assertion.asyncTypeName(), matcher, !desiredMatch, assertion.clock.Now().Sub(timer), 3+assertion.offset)
 	}
 
 	if assertion.asyncType == AsyncAssertionTypeEventually {
+		passingRun := 0
 		for {
+			if stopTrying != nil {
+				fail("Told to stop trying")
+				return false
+			}
+
+			if panicked != nil && assertion.failOnPanic {
+				fail("Polled function panicked")
+				return false
+			}
+
 			if err == nil && matches == desiredMatch {
-				return true
+				passingRun++
+				if passingRun > longestPassingRun {
+					longestPassingRun = passingRun
+				}
+				if passingRun >= assertion.mustPassRepeatedly || !mayChange {
+					pass()
+					return true
+				}
+			} else {
+				passingRun = 0
 			}
 
 			if !mayChange {
@@ -145,38 +541,39 @@ func (assertion *AsyncAssertion) match(matcher types.GomegaMatcher, desiredMatch
 				return false
 			}
 
-			select {
-			case <-time.After(assertion.pollingInterval):
-				value, err = assertion.pollActual()
-				if err == nil {
-					mayChange = assertion.matcherMayChange(matcher, value)
-					matches, err = matcher.Match(value)
-				}
-			case <-timeout:
+			switch waitAndPoll() {
+			case timedOut:
 				fail("Timed out")
 				return false
+			case cancelled:
+				fail("Context cancelled")
+				return false
 			}
 		}
 	} else if assertion.asyncType == AsyncAssertionTypeConsistently {
 		for {
+			if stopTrying != nil {
+				fail("Told to stop trying")
+				return false
+			}
+
 			if !(err == nil && matches == desiredMatch) {
 				fail("Failed")
 				return false
 			}
 
 			if !mayChange {
+				pass()
 				return true
 			}
 
-			select {
-			case <-time.After(assertion.pollingInterval):
-				value, err = assertion.pollActual()
-				if err == nil {
-					mayChange = assertion.matcherMayChange(matcher, value)
-					matches, err = matcher.Match(value)
-				}
-			case <-timeout:
+			switch waitAndPoll() {
+			case timedOut:
+				pass()
 				return true
+			case cancelled:
+				fail("Context cancelled")
+				return false
 			}
 		}
 	}
@@ -184,6 +581,13 @@ func (assertion *AsyncAssertion) match(matcher types.GomegaMatcher, desiredMatch
 	return false
 }
 
+func (assertion *AsyncAssertion) asyncTypeName() string {
+	if assertion.asyncType == AsyncAssertionTypeConsistently {
+		return "Consistently"
+	}
+	return "Eventually"
+}
+
 func vetExtras(extras []interface{}) (bool, string) {
 	for i, extra := range extras {
 		if extra != nil {
@@ -1,7 +1,10 @@
 package asyncassertion_test
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/onsi/gomega/internal/testingtsupport"
@@ -350,6 +353,18 @@ var _ = Describe("Async Assertion", func() {
 			Expect(func() {
 				asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() (int, error) { return 0, nil }, fakeFailWrapper, 0, 0, 1)
 			}).ShouldNot(Panic())
+
+			Expect(func() {
+				asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func(g Gomega) {}, fakeFailWrapper, 0, 0, 1)
+			}).ShouldNot(Panic())
+
+			Expect(func() {
+				asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func(g Gomega) (int, error) { return 0, nil }, fakeFailWrapper, 0, 0, 1)
+			}).ShouldNot(Panic())
+
+			Expect(func() {
+				asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func(g Gomega, a string) {}, fakeFailWrapper, 0, 0, 1)
+			}).Should(Panic())
 		})
 	})
 
@@ -386,4 +401,818 @@ var _ = Describe("Async Assertion", func() {
 			})
 		})
 	})
+
+	Describe("with a context", func() {
+		It("Eventually should stop polling and fail as soon as the context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				counter++
+				if counter == 3 {
+					cancel()
+				}
+				return counter
+			}, fakeFailWrapper, time.Second, time.Duration(0.01*float64(time.Second)), 1).WithContext(ctx)
+
+			t := time.Now()
+			Expect(a.Should(BeNumerically(">", 100), "My description %d", 2)).Should(BeFalse())
+			Expect(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
+
+			Expect(counter).Should(Equal(3))
+			Expect(failureMessage).Should(ContainSubstring("Context cancelled after"))
+			Expect(failureMessage).Should(ContainSubstring("<int>: 3"), "Should report the last observed value.")
+			Expect(failureMessage).Should(ContainSubstring("My description 2"))
+			Expect(callerSkip).Should(Equal(4))
+		})
+
+		It("Eventually should still pass if the value matches before the context is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				counter++
+				return counter
+			}, fakeFailWrapper, time.Second, time.Duration(0.01*float64(time.Second)), 1).WithContext(ctx)
+
+			Expect(a.Should(Equal(3))).Should(BeTrue())
+			Expect(failureMessage).Should(BeZero())
+		})
+
+		It("Consistently should fail if the context is cancelled before the duration elapses", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				cancel()
+			}()
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeConsistently, func() string {
+				return "foo"
+			}, fakeFailWrapper, time.Second, time.Duration(0.01*float64(time.Second)), 1).WithContext(ctx)
+
+			t := time.Now()
+			Expect(a.Should(Equal("foo"))).Should(BeFalse())
+			Expect(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
+			Expect(failureMessage).Should(ContainSubstring("Context cancelled after"))
+		})
+
+		It("should accept the context among the intervals passed to Eventually and Consistently", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			t := time.Now()
+			failures := InterceptGomegaFailures(func() {
+				Eventually(func() int { return 0 }, ctx, 1).Should(Equal(1))
+				Consistently(func() int { return 0 }, 1, ctx).Should(Equal(0))
+			})
+			Expect(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
+
+			Expect(failures).Should(HaveLen(2))
+			Expect(failures[0]).Should(ContainSubstring("Context cancelled after"))
+			Expect(failures[1]).Should(ContainSubstring("Context cancelled after"))
+		})
+	})
+
+	Describe("polling a function that takes a Gomega", func() {
+		It("Eventually should pass once every assertion made in the function passes", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func(g Gomega) {
+				counter++
+				g.Expect(counter).Should(BeNumerically(">", 2))
+				g.Expect(counter % 2).Should(Equal(0))
+			}, fakeFailWrapper, time.Duration(0.2*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(Succeed())).Should(BeTrue())
+			Expect(counter).Should(Equal(4))
+			Expect(failureMessage).Should(BeZero())
+		})
+
+		It("should stop the function at the first failed assertion and report the last failure on timeout", func() {
+			counter := 0
+			reachedEnd := false
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func(g Gomega) {
+				counter++
+				g.Expect(counter).Should(BeNumerically("<", 0), "counter should be negative")
+				reachedEnd = true
+			}, fakeFailWrapper, time.Duration(0.1*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(Succeed())).Should(BeFalse())
+			Expect(reachedEnd).Should(BeFalse())
+			Expect(failureMessage).Should(ContainSubstring("Timed out after"))
+			Expect(failureMessage).Should(ContainSubstring("Assertion in callback at"))
+			Expect(failureMessage).Should(ContainSubstring("async_assertion_test.go"))
+			Expect(failureMessage).Should(ContainSubstring("counter should be negative"))
+			Expect(failureMessage).Should(MatchRegexp(`<int>: %d\s`, counter), "Should report the last failure.")
+			Expect(callerSkip).Should(Equal(4))
+		})
+
+		It("should pass the function's first return value to the matcher", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func(g Gomega) (int, error) {
+				counter++
+				g.Expect(counter).Should(BeNumerically(">", 1))
+				return counter * 10, nil
+			}, fakeFailWrapper, time.Duration(0.2*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(Equal(30))).Should(BeTrue())
+			Expect(failureMessage).Should(BeZero())
+		})
+
+		It("Consistently should fail as soon as an assertion in the function fails", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeConsistently, func(g Gomega) {
+				counter++
+				g.Expect(counter).Should(BeNumerically("<", 3))
+			}, fakeFailWrapper, time.Duration(0.2*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(Succeed())).Should(BeFalse())
+			Expect(counter).Should(Equal(3))
+			Expect(failureMessage).Should(ContainSubstring("Assertion in callback at"))
+		})
+
+		It("should recover panics that are not assertion failures", func() {
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func(g Gomega) {
+				panic("boom")
+			}, fakeFailWrapper, time.Duration(0.1*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(func() { a.Should(Succeed()) }).ShouldNot(Panic())
+			Expect(failureMessage).Should(ContainSubstring("Error: The polled function panicked:\nboom"))
+		})
+
+		It("should support nested Eventually calls on the passed-in Gomega", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func(g Gomega) {
+				counter++
+				g.Eventually(func() int { return counter }, 0.02, 0.005).Should(BeNumerically(">", 2))
+			}, fakeFailWrapper, time.Duration(0.2*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(Succeed())).Should(BeTrue())
+			Expect(counter).Should(Equal(3))
+		})
+	})
+
+	Describe("configuring intervals with chained methods", func() {
+		It("should use the configured timeout and polling interval", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				counter++
+				return counter
+			}, fakeFailWrapper, time.Second, time.Second, 1)
+
+			t := time.Now()
+			Expect(a.WithTimeout(100 * time.Millisecond).WithPolling(10 * time.Millisecond).Should(BeNumerically(">", 100))).Should(BeFalse())
+			Expect(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
+			Expect(counter).Should(BeNumerically(">", 5))
+			Expect(failureMessage).Should(ContainSubstring("Timed out after 0.1"))
+		})
+
+		It("should support Within and ProbeEvery as aliases", func() {
+			calls := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeConsistently, func() string {
+				calls++
+				return "foo"
+			}, fakeFailWrapper, time.Second, time.Second, 1)
+
+			t := time.Now()
+			Expect(a.Within(100 * time.Millisecond).ProbeEvery(10 * time.Millisecond).Should(Equal("foo"))).Should(BeTrue())
+			Expect(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
+			Expect(calls).Should(BeNumerically(">", 5))
+			Expect(failureMessage).Should(BeZero())
+		})
+
+		It("should fail without polling when given invalid durations", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				counter++
+				return counter
+			}, fakeFailWrapper, time.Second, time.Second, 1)
+
+			Expect(a.WithTimeout(-time.Second).ProbeEvery(0).Should(Equal(1), "My description %d", 2)).Should(BeFalse())
+			Expect(counter).Should(BeZero())
+			Expect(failureMessage).Should(ContainSubstring("My description 2"))
+			Expect(failureMessage).Should(ContainSubstring("Invalid arguments passed to Eventually"))
+			Expect(failureMessage).Should(ContainSubstring("WithTimeout: the timeout must not be negative, got -1s"))
+			Expect(failureMessage).Should(ContainSubstring("WithPolling: the polling interval must be positive, got 0s"))
+			Expect(callerSkip).Should(Equal(3))
+		})
+
+		It("should fail instead of panicking when given unparsable positional intervals", func() {
+			a := asyncassertion.NewWithIntervals(asyncassertion.AsyncAssertionTypeConsistently, func() int { return 0 }, fakeFailWrapper, time.Second, time.Second, 1, "boop", true)
+
+			Expect(a.Should(Equal(0))).Should(BeFalse())
+			Expect(failureMessage).Should(ContainSubstring("Invalid arguments passed to Consistently"))
+			Expect(failureMessage).Should(ContainSubstring(`timeout: "boop" is not a valid parsable duration string.`))
+			Expect(failureMessage).Should(ContainSubstring("polling interval: true is not a valid interval."))
+		})
+	})
+
+	Describe("StopTrying", func() {
+		It("Eventually should stop and fail when the function returns a StopTrying error", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() (string, error) {
+				counter++
+				if counter == 3 {
+					return "Failed", StopTrying("the job failed")
+				}
+				return "Pending", nil
+			}, fakeFailWrapper, time.Second, time.Duration(0.01*float64(time.Second)), 1)
+
+			t := time.Now()
+			Expect(a.Should(Equal("Succeeded"), "My description %d", 2)).Should(BeFalse())
+			Expect(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
+			Expect(counter).Should(Equal(3))
+			Expect(failureMessage).Should(ContainSubstring("Told to stop trying after"))
+			Expect(failureMessage).Should(ContainSubstring("My description 2"))
+			Expect(failureMessage).Should(ContainSubstring("Error: the job failed"))
+			Expect(failureMessage).Should(ContainSubstring("Last observed value:\n    <string>: Failed"))
+			Expect(callerSkip).Should(Equal(4))
+		})
+
+		It("should stop when the only returned value is a wrapped StopTrying error", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() error {
+				counter++
+				return fmt.Errorf("giving up: %w", StopTrying("no luck").Wrap(errors.New("boom")))
+			}, fakeFailWrapper, time.Second, time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(Succeed())).Should(BeFalse())
+			Expect(counter).Should(Equal(1))
+			Expect(failureMessage).Should(ContainSubstring("Error: giving up: no luck: boom"))
+			Expect(failureMessage).ShouldNot(ContainSubstring("Last observed value"))
+		})
+
+		It("should stop when the function panics with StopTrying and report the last value it returned", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				counter++
+				if counter == 3 {
+					panic(StopTrying("enough"))
+				}
+				return counter
+			}, fakeFailWrapper, time.Second, time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(BeNumerically(">", 10))).Should(BeFalse())
+			Expect(counter).Should(Equal(3))
+			Expect(failureMessage).Should(ContainSubstring("Told to stop trying after"))
+			Expect(failureMessage).Should(ContainSubstring("Error: enough"))
+			Expect(failureMessage).Should(ContainSubstring("<int>: 2"))
+		})
+
+		It("Consistently should stop and fail", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeConsistently, func(g Gomega) int {
+				counter++
+				if counter == 3 {
+					panic(StopTrying("enough"))
+				}
+				return counter
+			}, fakeFailWrapper, time.Second, time.Duration(0.01*float64(time.Second)), 1)
+
+			t := time.Now()
+			Expect(a.Should(BeNumerically("<", 10))).Should(BeFalse())
+			Expect(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
+			Expect(failureMessage).Should(ContainSubstring("Told to stop trying after"))
+		})
+	})
+
+	Describe("polling a function that panics", func() {
+		It("Eventually should keep polling until the function stops panicking", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				counter++
+				if counter < 3 {
+					panic("not ready yet")
+				}
+				return counter
+			}, fakeFailWrapper, time.Second, time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(Equal(3))).Should(BeTrue())
+			Expect(failureMessage).Should(BeZero())
+		})
+
+		It("should report the last panic's value and stack when Eventually times out", func() {
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				panic("client not started")
+			}, fakeFailWrapper, time.Duration(0.1*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(Equal(3))).Should(BeFalse())
+			Expect(failureMessage).Should(HavePrefix("Timed out after"))
+			Expect(failureMessage).Should(ContainSubstring("Error: The polled function panicked:\nclient not started\n\n"))
+			Expect(failureMessage).Should(ContainSubstring("async_assertion_test.go"))
+			Expect(callerSkip).Should(Equal(4))
+		})
+
+		It("should record panics in the timeline", func() {
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				panic(errors.New("boom"))
+			}, fakeFailWrapper, time.Duration(0.05*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1).WithTimeline(true)
+
+			Expect(a.Should(Equal(3))).Should(BeFalse())
+			Expect(failureMessage).Should(ContainSubstring("no value (panicked: boom)"))
+		})
+
+		It("should fail at the first panic with WithFailOnPanic", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func(g Gomega) int {
+				counter++
+				panic("boom")
+			}, fakeFailWrapper, time.Second, time.Duration(0.01*float64(time.Second)), 1).WithFailOnPanic(true)
+
+			Expect(a.Should(Equal(3))).Should(BeFalse())
+			Expect(counter).Should(Equal(1))
+			Expect(failureMessage).Should(HavePrefix("Polled function panicked after"))
+			Expect(failureMessage).Should(ContainSubstring("Error: The polled function panicked:\nboom"))
+			Expect(callerSkip).Should(Equal(4))
+		})
+
+		It("Consistently should fail at the first panic", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeConsistently, func() int {
+				counter++
+				if counter == 3 {
+					panic("boom")
+				}
+				return counter
+			}, fakeFailWrapper, time.Second, time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(BeNumerically("<", 10))).Should(BeFalse())
+			Expect(counter).Should(Equal(3))
+			Expect(failureMessage).Should(HavePrefix("Failed after"))
+			Expect(failureMessage).Should(ContainSubstring("Error: The polled function panicked:\nboom"))
+		})
+
+		It("should not recover the panic of a fail handler when a global assertion in the polled function fails", func() {
+			RegisterFailHandler(func(message string, callerSkip ...int) {
+				panic("halting the spec: " + message)
+			})
+			counter, finished := 0, false
+			var panicked interface{}
+			func() {
+				defer func() {
+					panicked = recover()
+				}()
+				asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+					counter++
+					Expect(counter).Should(Equal(5))
+					return counter
+				}, fakeFailWrapper, time.Second, time.Duration(0.01*float64(time.Second)), 1).Should(Equal(5))
+				finished = true
+			}()
+			RegisterFailHandler(Fail)
+
+			Expect(panicked).Should(HavePrefix("halting the spec: "))
+			Expect(counter).Should(Equal(1))
+			Expect(finished).Should(BeFalse())
+			Expect(failureMessage).Should(BeZero())
+		})
+
+		It("should recover the polled function's panics while assertions fail elsewhere", func() {
+			stop := make(chan bool)
+			checking := &sync.WaitGroup{}
+			checking.Add(1)
+			go func() {
+				defer checking.Done()
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+						Check(1).To(Equal(2))
+					}
+				}
+			}()
+			defer func() {
+				close(stop)
+				checking.Wait()
+			}()
+
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				counter++
+				if counter < 3 {
+					panic("starting up")
+				}
+				return 1
+			}, fakeFailWrapper, time.Second, time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(Equal(1))).Should(BeTrue())
+			Expect(counter).Should(Equal(3))
+			Expect(failureMessage).Should(BeZero())
+		})
+
+		It("should recover the polled function's panics after it makes a failing Check", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				counter++
+				if Check(counter).To(BeNumerically(">=", 3)) != nil {
+					panic("starting up")
+				}
+				return counter
+			}, fakeFailWrapper, time.Second, time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(Equal(3))).Should(BeTrue())
+			Expect(failureMessage).Should(BeZero())
+		})
+
+		It("should not recover Ginkgo's panic when the polled function calls Fail", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				counter++
+				panic("\nYour test failed.\nGinkgo panics to prevent subsequent assertions from running.\n")
+			}, fakeFailWrapper, time.Second, time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(func() { a.Should(Equal(3)) }).Should(PanicWith(ContainSubstring("Ginkgo panics")))
+			Expect(counter).Should(Equal(1))
+		})
+	})
+
+	Describe("receiving from a channel", func() {
+		It("Eventually should react to a value as soon as it arrives rather than waiting for the next poll", func() {
+			c := make(chan int)
+			go func() {
+				time.Sleep(20 * time.Millisecond)
+				c <- 17
+			}()
+
+			var received int
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, c, fakeFailWrapper, time.Second, 500*time.Millisecond, 1)
+
+			t := time.Now()
+			Expect(a.Should(Receive(&received))).Should(BeTrue())
+			Expect(time.Since(t)).Should(BeNumerically("<", 300*time.Millisecond))
+			Expect(received).Should(Equal(17))
+		})
+
+		It("should not drop values between polls on a buffered channel", func() {
+			c := make(chan int, 10)
+			go func() {
+				for i := 1; i <= 10; i++ {
+					c <- i
+				}
+			}()
+
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, c, fakeFailWrapper, time.Second, 500*time.Millisecond, 1)
+			Expect(a.Should(Receive(Equal(10)))).Should(BeTrue())
+			Expect(c).Should(BeEmpty())
+		})
+
+		It("should report how many values were received, and the last one, on failure", func() {
+			c := make(chan string, 3)
+			c <- "a"
+			c <- "b"
+			c <- "c"
+
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, c, fakeFailWrapper, time.Duration(0.1*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+			Expect(a.Should(Receive(Equal("d")))).Should(BeFalse())
+
+			Expect(failureMessage).Should(ContainSubstring("Timed out after"))
+			Expect(failureMessage).Should(ContainSubstring("Received 3 values from the channel, the last was:\n    <string>: c"))
+			Expect(callerSkip).Should(Equal(4))
+		})
+
+		It("should say when nothing was received", func() {
+			c := make(chan string)
+
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, c, fakeFailWrapper, time.Duration(0.05*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+			Expect(a.Should(Receive())).Should(BeFalse())
+
+			Expect(failureMessage).Should(ContainSubstring("to receive something."))
+			Expect(failureMessage).Should(ContainSubstring("Nothing was received from the channel."))
+		})
+
+		It("Consistently should report the value that arrived", func() {
+			c := make(chan bool)
+			go func() {
+				time.Sleep(20 * time.Millisecond)
+				c <- true
+			}()
+
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeConsistently, c, fakeFailWrapper, time.Duration(0.2*float64(time.Second)), time.Duration(0.05*float64(time.Second)), 1)
+			Expect(a.ShouldNot(Receive())).Should(BeFalse())
+
+			Expect(failureMessage).Should(ContainSubstring("not to receive anything"))
+			Expect(failureMessage).Should(ContainSubstring("Received 1 value from the channel:\n    <bool>: true"))
+		})
+
+		It("should bail out early when the channel is closed", func() {
+			c := make(chan bool)
+			close(c)
+
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, c, fakeFailWrapper, time.Second, time.Duration(0.01*float64(time.Second)), 1)
+
+			t := time.Now()
+			Expect(a.Should(Receive())).Should(BeFalse())
+			Expect(time.Since(t)).Should(BeNumerically("<", 500*time.Millisecond))
+			Expect(failureMessage).Should(ContainSubstring("No future change is possible"))
+			Expect(failureMessage).Should(ContainSubstring("The channel is closed."))
+		})
+	})
+
+	Describe("recording a timeline", func() {
+		It("should not include a timeline by default", func() {
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int { return 0 }, fakeFailWrapper, time.Duration(0.05*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+			Expect(a.Should(Equal(1))).Should(BeFalse())
+			Expect(failureMessage).ShouldNot(ContainSubstring("Timeline:"))
+		})
+
+		It("should show each poll and collapse repeated values", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() []int {
+				counter++
+				if counter == 4 {
+					return []int{1, 2, 3, 4}
+				}
+				return []int{1, 2, 3}
+			}, fakeFailWrapper, time.Duration(0.1*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1).WithTimeline(true)
+
+			Expect(a.Should(HaveLen(5))).Should(BeFalse())
+			Expect(failureMessage).Should(ContainSubstring("Timeline:\n"))
+			Expect(failureMessage).Should(MatchRegexp(`\n  t\+0\.00s\.\.t\+0\.\d\ds: 3 items \(no match\) x3\n  t\+0\.\d\ds: 4 items \(no match\)\n  t\+0\.\d\ds\.\.t\+0\.\d\ds: 3 items \(no match\) x\d+$`))
+		})
+
+		It("should show errors and the poll that matched", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeConsistently, func() (string, error) {
+				counter++
+				if counter == 3 {
+					return "", errors.New("boom")
+				}
+				return "foo", nil
+			}, fakeFailWrapper, time.Duration(0.2*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1).WithTimeline(true)
+
+			Expect(a.Should(Equal("foo"))).Should(BeFalse())
+			Expect(failureMessage).Should(MatchRegexp(`Timeline:\n  t\+0\.00s\.\.t\+0\.\d\ds: "foo" \(match\) x2\n  t\+0\.\d\ds: no value \(error: Unexpected non-nil/non-zero extra argume\.\.\.\)$`))
+		})
+
+		It("should show what was received from a channel", func() {
+			c := make(chan int, 2)
+			c <- 1
+			c <- 2
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, c, fakeFailWrapper, time.Duration(0.05*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1).WithTimeline(true)
+
+			Expect(a.Should(Receive(Equal(3)))).Should(BeFalse())
+			Expect(failureMessage).Should(ContainSubstring("received 1 (no match)"))
+			Expect(failureMessage).Should(ContainSubstring("received 2 (no match)"))
+			Expect(failureMessage).Should(ContainSubstring("nothing received (no match)"))
+		})
+
+		It("should be possible to turn on for every assertion", func() {
+			SetDefaultAsyncTimeline(true)
+			defer SetDefaultAsyncTimeline(false)
+
+			failures := InterceptGomegaFailures(func() {
+				Eventually(func() string { return "foo" }, 0.05, 0.01).Should(Equal("bar"))
+			})
+			Expect(failures).Should(HaveLen(1))
+			Expect(failures[0]).Should(ContainSubstring(`"foo" (no match)`))
+		})
+	})
+
+	Describe("MustPassRepeatedly", func() {
+		It("should only pass once the matcher has passed the requested number of consecutive times", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				counter++
+				if counter == 3 {
+					return 0
+				}
+				return 1
+			}, fakeFailWrapper, time.Duration(0.2*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1).MustPassRepeatedly(4)
+
+			Expect(a.Should(Equal(1))).Should(BeTrue())
+			Expect(counter).Should(Equal(7))
+			Expect(failureMessage).Should(BeZero())
+		})
+
+		It("should report the longest run of consecutive passes on timeout", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				counter++
+				return counter % 3
+			}, fakeFailWrapper, time.Duration(0.1*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1).MustPassRepeatedly(3)
+
+			Expect(a.Should(BeNumerically(">", 0))).Should(BeFalse())
+			Expect(failureMessage).Should(ContainSubstring("Timed out after"))
+			Expect(failureMessage).Should(ContainSubstring("MustPassRepeatedly(3): at most 2 consecutive polls passed"))
+			Expect(callerSkip).Should(Equal(4))
+		})
+
+		It("should not show a misleading matcher message when the last poll passed", func() {
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				return 1
+			}, fakeFailWrapper, time.Duration(0.05*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1).MustPassRepeatedly(100)
+
+			Expect(a.Should(Equal(1))).Should(BeFalse())
+			Expect(failureMessage).Should(ContainSubstring("The last poll passed.  Last observed value:\n    <int>: 1"))
+			Expect(failureMessage).ShouldNot(ContainSubstring("to equal"))
+		})
+
+		It("should reject invalid counts and Consistently", func() {
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int { return 1 }, fakeFailWrapper, time.Second, time.Second, 1).MustPassRepeatedly(0)
+			Expect(a.Should(Equal(1))).Should(BeFalse())
+			Expect(failureMessage).Should(ContainSubstring("MustPassRepeatedly: the count must be at least 1, got 0"))
+
+			a = asyncassertion.New(asyncassertion.AsyncAssertionTypeConsistently, func() int { return 1 }, fakeFailWrapper, time.Second, time.Second, 1).MustPassRepeatedly(2)
+			Expect(a.Should(Equal(1))).Should(BeFalse())
+			Expect(failureMessage).Should(ContainSubstring("MustPassRepeatedly: only Eventually supports MustPassRepeatedly"))
+		})
+	})
+
+	Describe("polling strategies", func() {
+		It("should poll at a fixed interval by default", func() {
+			Expect(asyncassertion.FixedPollingStrategy{}.NextPollingInterval(10*time.Millisecond, 1)).Should(Equal(10 * time.Millisecond))
+			Expect(asyncassertion.FixedPollingStrategy{}.NextPollingInterval(10*time.Millisecond, 7)).Should(Equal(10 * time.Millisecond))
+		})
+
+		It("should back off exponentially up to the maximum interval", func() {
+			strategy := asyncassertion.ExponentialBackoffPollingStrategy{Factor: 2, MaxInterval: 50 * time.Millisecond}
+			Expect(strategy.NextPollingInterval(10*time.Millisecond, 1)).Should(Equal(10 * time.Millisecond))
+			Expect(strategy.NextPollingInterval(10*time.Millisecond, 2)).Should(Equal(20 * time.Millisecond))
+			Expect(strategy.NextPollingInterval(10*time.Millisecond, 3)).Should(Equal(40 * time.Millisecond))
+			Expect(strategy.NextPollingInterval(10*time.Millisecond, 4)).Should(Equal(50 * time.Millisecond))
+			Expect(strategy.NextPollingInterval(10*time.Millisecond, 40)).Should(Equal(50 * time.Millisecond))
+		})
+
+		It("should jitter another strategy's intervals", func() {
+			strategy := asyncassertion.JitteredPollingStrategy{Strategy: asyncassertion.FixedPollingStrategy{}, Fraction: 0.5}
+			seen := map[time.Duration]bool{}
+			for i := 0; i < 100; i++ {
+				interval := strategy.NextPollingInterval(100*time.Millisecond, i+1)
+				Expect(interval).Should(BeNumerically("~", 100*time.Millisecond, 50*time.Millisecond))
+				seen[interval] = true
+			}
+			Expect(len(seen)).Should(BeNumerically(">", 1))
+		})
+
+		It("should use the strategy to space out polls", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				counter++
+				return counter
+			}, fakeFailWrapper, time.Duration(0.2*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+
+			a.WithPollingStrategy(asyncassertion.ExponentialBackoffPollingStrategy{Factor: 2, MaxInterval: time.Second}).Should(BeNumerically(">", 100))
+			Expect(counter).Should(BeNumerically("<=", 5))
+			Expect(failureMessage).Should(ContainSubstring("Timed out after"))
+		})
+
+		It("should fall back to the polling interval when the strategy returns a non-positive interval", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				counter++
+				return counter
+			}, fakeFailWrapper, time.Duration(0.1*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+
+			a.WithPollingStrategy(asyncassertion.ExponentialBackoffPollingStrategy{Factor: 0, MaxInterval: time.Second}).Should(BeNumerically(">", 100))
+			Expect(counter).Should(BeNumerically("<=", 11))
+		})
+	})
+
+	Describe("with a fake clock", func() {
+		var clock *fakeClock
+		var stopAdvancing chan struct{}
+
+		BeforeEach(func() {
+			clock = newFakeClock()
+			stopAdvancing = make(chan struct{})
+			go func(clock *fakeClock, stop chan struct{}) {
+				for {
+					select {
+					case <-stop:
+						return
+					case <-time.After(time.Millisecond):
+						clock.Advance(time.Minute)
+					}
+				}
+			}(clock, stopAdvancing)
+		})
+
+		AfterEach(func() {
+			close(stopAdvancing)
+		})
+
+		It("should take its timeout and polling interval from the clock", func() {
+			start := clock.Now()
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() time.Duration {
+				return clock.Now().Sub(start)
+			}, fakeFailWrapper, 2*time.Hour, time.Minute, 1).WithClock(clock)
+
+			t := time.Now()
+			Expect(a.Should(BeNumerically(">=", 30*time.Minute))).Should(BeTrue())
+			Expect(time.Since(t)).Should(BeNumerically("<", time.Second))
+			Expect(failureMessage).Should(BeZero())
+		})
+
+		It("should report elapsed time according to the clock", func() {
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() int {
+				return 0
+			}, fakeFailWrapper, time.Hour, 10*time.Minute, 1).WithClock(clock)
+
+			t := time.Now()
+			Expect(a.Should(Equal(1))).Should(BeFalse())
+			Expect(time.Since(t)).Should(BeNumerically("<", time.Second))
+			Expect(failureMessage).Should(MatchRegexp(`Timed out after 36\d\d\.000s`))
+		})
+
+		It("should be possible to set the clock for every assertion", func() {
+			SetDefaultClock(clock)
+			defer SetDefaultClock(nil)
+
+			t := time.Now()
+			failures := InterceptGomegaFailures(func() {
+				Consistently(func() int { return 0 }, time.Hour, time.Minute).Should(Equal(0))
+				Eventually(func() int { return 0 }, time.Hour, time.Minute).Should(Equal(1))
+			})
+			Expect(time.Since(t)).Should(BeNumerically("<", time.Second))
+			Expect(failures).Should(HaveLen(1))
+			Expect(failures[0]).Should(MatchRegexp(`Timed out after 36\d\d\.000s`))
+		})
+	})
+
+	Describe("matching every return value", func() {
+		It("should hand all of the return values to a ReturnValues matcher", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() (int, string, error) {
+				counter++
+				return counter, "xyz", nil
+			}, fakeFailWrapper, time.Duration(0.2*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(ReturnValues(Equal(3), HavePrefix("x"), Succeed()))).Should(BeTrue())
+			Expect(counter).Should(Equal(3))
+			Expect(failureMessage).Should(BeZero())
+		})
+
+		It("should name the return value that did not match", func() {
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() (int, error) {
+				return 3, errors.New("boom")
+			}, fakeFailWrapper, time.Duration(0.05*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(ReturnValues(Equal(3), Succeed()))).Should(BeFalse())
+			Expect(failureMessage).Should(ContainSubstring("Return value at index 1 did not match:\nExpected success, but got an error:"))
+			Expect(callerSkip).Should(Equal(4))
+		})
+
+		It("should hand all of the return values to a ReturnValues matcher wrapped in another matcher", func() {
+			counter := 0
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() (int, error) {
+				counter++
+				return counter, nil
+			}, fakeFailWrapper, time.Duration(0.2*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(DescribedAs("fetch", Not(ReturnValues(BeNumerically("<", 3), Succeed()))))).Should(BeTrue())
+			Expect(counter).Should(Equal(3))
+			Expect(failureMessage).Should(BeZero())
+		})
+
+		It("should still require other matchers' extra return values to be zero", func() {
+			a := asyncassertion.New(asyncassertion.AsyncAssertionTypeEventually, func() (int, error) {
+				return 3, errors.New("boom")
+			}, fakeFailWrapper, time.Duration(0.05*float64(time.Second)), time.Duration(0.01*float64(time.Second)), 1)
+
+			Expect(a.Should(Equal(3))).Should(BeFalse())
+			Expect(failureMessage).Should(ContainSubstring("Unexpected non-nil/non-zero extra argument at index 1"))
+		})
+	})
 })
+
+type fakeClockWaiter struct {
+	deadline time.Time
+	c        chan time.Time
+}
+
+type fakeClock struct {
+	lock    sync.Mutex
+	now     time.Time
+	waiters []fakeClockWaiter
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2021, 5, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (clock *fakeClock) Now() time.Time {
+	clock.lock.Lock()
+	defer clock.lock.Unlock()
+	return clock.now
+}
+
+func (clock *fakeClock) After(d time.Duration) <-chan time.Time {
+	clock.lock.Lock()
+	defer clock.lock.Unlock()
+	c := make(chan time.Time, 1)
+	clock.waiters = append(clock.waiters, fakeClockWaiter{deadline: clock.now.Add(d), c: c})
+	return c
+}
+
+func (clock *fakeClock) Advance(d time.Duration) {
+	clock.lock.Lock()
+	defer clock.lock.Unlock()
+	clock.now = clock.now.Add(d)
+	waiters := []fakeClockWaiter{}
+	for _, waiter := range clock.waiters {
+		if waiter.deadline.After(clock.now) {
+			waiters = append(waiters, waiter)
+		} else {
+			waiter.c <- clock.now
+		}
+	}
+	clock.waiters = waiters
+}
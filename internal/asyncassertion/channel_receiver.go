@@ -0,0 +1,73 @@
+package asyncassertion
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/format"
+)
+
+// channelReceiver receives values from the channel passed to Eventually or Consistently on behalf of a Receive matcher.
+// Each value is handed to the matcher through a stand-in channel, so the assertion can block until something arrives
+// rather than polling, and can report what it saw.
+type channelReceiver struct {
+	channel      reflect.Value
+	count        int
+	lastReceived reflect.Value
+	didReceive   bool
+}
+
+func newChannelReceiver(channel interface{}) *channelReceiver {
+	return &channelReceiver{
+		channel: reflect.ValueOf(channel),
+	}
+}
+
+// receiveNow returns a stand-in channel holding whatever the channel has ready, without blocking.
+func (receiver *channelReceiver) receiveNow() interface{} {
+	chosen, received, open := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: receiver.channel},
+		{Dir: reflect.SelectDefault},
+	})
+	return receiver.standIn(received, open, chosen == 0)
+}
+
+// receive blocks until the channel yields a value or one of waitOn fires.
+// It returns the index of the waitOn channel that fired, or -1 and a stand-in channel holding the received value.
+func (receiver *channelReceiver) receive(waitOn ...interface{}) (int, interface{}) {
+	cases := []reflect.SelectCase{{Dir: reflect.SelectRecv, Chan: receiver.channel}}
+	for _, c := range waitOn {
+		cases = append(cases, reflect.SelectCase{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(c)})
+	}
+
+	chosen, received, open := reflect.Select(cases)
+	if chosen > 0 {
+		return chosen - 1, nil
+	}
+	return -1, receiver.standIn(received, open, true)
+}
+
+func (receiver *channelReceiver) standIn(received reflect.Value, open bool, didReceive bool) interface{} {
+	standIn := reflect.MakeChan(reflect.ChanOf(reflect.BothDir, receiver.channel.Type().Elem()), 1)
+	receiver.didReceive = didReceive && open
+	if didReceive {
+		if open {
+			standIn.Send(received)
+			receiver.count++
+			receiver.lastReceived = received
+		} else {
+			standIn.Close()
+		}
+	}
+	return standIn.Interface()
+}
+
+func (receiver *channelReceiver) summary() string {
+	switch receiver.count {
+	case 0:
+		return "Nothing was received from the channel."
+	case 1:
+		return fmt.Sprintf("Received 1 value from the channel:\n%s", format.Object(receiver.lastReceived.Interface(), 1))
+	}
+	return fmt.Sprintf("Received %d values from the channel, the last was:\n%s", receiver.count, format.Object(receiver.lastReceived.Interface(), 1))
+}
@@ -0,0 +1,14 @@
+package asyncassertion
+
+import "time"
+
+// realClock is the Clock used unless a fake one is configured.
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -0,0 +1,33 @@
+package asyncassertion
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/internal/failure"
+)
+
+// ginkgoPanic is part of the value Ginkgo's Fail panics with once it has recorded a failure.
+const ginkgoPanic = "Ginkgo panics to prevent subsequent assertions from running."
+
+// polledPanic is the error a poll results in when the polled function panics.  The panic counts as a poll that did
+// not match unless the assertion was told to fail on panics.
+type polledPanic struct {
+	value interface{}
+	stack []byte
+}
+
+func (p *polledPanic) Error() string {
+	return fmt.Sprintf("The polled function panicked:\n%v\n\n%s", p.value, p.stack)
+}
+
+// raisedByFailHandler is true when a panic out of the polled function was raised by a fail handler, such as Ginkgo's
+// Fail, rather than by the function itself.  Such panics halt the test and must not be recovered.  It must be called
+// from the deferred function recovering the panic.
+func raisedByFailHandler(e interface{}) bool {
+	if failure.RaisedByFailHandler() {
+		return true
+	}
+	message, ok := e.(string)
+	return ok && strings.Contains(message, ginkgoPanic)
+}
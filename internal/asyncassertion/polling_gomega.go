@@ -0,0 +1,55 @@
+package asyncassertion
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"github.com/onsi/gomega/internal/assertion"
+	"github.com/onsi/gomega/types"
+)
+
+// pollingFailure is panicked by a pollingGomega's fail handler to halt the polled function at the first failed assertion.
+type pollingFailure struct {
+	message string
+}
+
+// pollingGomega is the Gomega handed to polled functions of the form func(g Gomega).
+// Failed assertions do not fail the test; they mark the current poll as not yet matched.
+type pollingGomega struct {
+	failWrapper     *types.GomegaFailWrapper
+	timeoutInterval time.Duration
+	pollingInterval time.Duration
+	clock           types.Clock
+}
+
+func newPollingGomega(parent *AsyncAssertion) pollingGomega {
+	return pollingGomega{
+		failWrapper: &types.GomegaFailWrapper{
+			Fail: func(message string, callerSkip ...int) {
+				skip := 0
+				if len(callerSkip) > 0 {
+					skip = callerSkip[0]
+				}
+				_, file, line, _ := runtime.Caller(skip + 1)
+				panic(pollingFailure{message: fmt.Sprintf("Assertion in callback at %s:%d failed:\n%s", file, line, message)})
+			},
+			TWithHelper: parent.failWrapper.TWithHelper,
+		},
+		timeoutInterval: parent.timeoutInterval,
+		pollingInterval: parent.pollingInterval,
+		clock:           parent.clock,
+	}
+}
+
+func (g pollingGomega) Expect(actual interface{}, extra ...interface{}) types.Assertion {
+	return assertion.New(actual, g.failWrapper, 0, extra...)
+}
+
+func (g pollingGomega) Eventually(actual interface{}, intervals ...interface{}) types.AsyncAssertion {
+	return NewWithIntervals(AsyncAssertionTypeEventually, actual, g.failWrapper, g.timeoutInterval, g.pollingInterval, 0, intervals...).WithClock(g.clock)
+}
+
+func (g pollingGomega) Consistently(actual interface{}, intervals ...interface{}) types.AsyncAssertion {
+	return NewWithIntervals(AsyncAssertionTypeConsistently, actual, g.failWrapper, g.timeoutInterval, g.pollingInterval, 0, intervals...).WithClock(g.clock)
+}
@@ -0,0 +1,55 @@
+package asyncassertion
+
+import (
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/onsi/gomega/types"
+)
+
+// FixedPollingStrategy always waits for the configured polling interval.
+type FixedPollingStrategy struct{}
+
+func (FixedPollingStrategy) NextPollingInterval(pollingInterval time.Duration, polls int) time.Duration {
+	return pollingInterval
+}
+
+// ExponentialBackoffPollingStrategy starts at the configured polling interval and multiplies it by Factor after
+// every poll, never waiting longer than MaxInterval.
+type ExponentialBackoffPollingStrategy struct {
+	Factor      float64
+	MaxInterval time.Duration
+}
+
+func (strategy ExponentialBackoffPollingStrategy) NextPollingInterval(pollingInterval time.Duration, polls int) time.Duration {
+	interval := float64(pollingInterval) * math.Pow(strategy.Factor, float64(polls-1))
+	if strategy.MaxInterval > 0 && interval > float64(strategy.MaxInterval) {
+		return strategy.MaxInterval
+	}
+	return time.Duration(interval)
+}
+
+// JitteredPollingStrategy randomly lengthens or shortens the intervals of another strategy by up to Fraction of their length.
+type JitteredPollingStrategy struct {
+	Strategy types.PollingStrategy
+	Fraction float64
+}
+
+func (strategy JitteredPollingStrategy) NextPollingInterval(pollingInterval time.Duration, polls int) time.Duration {
+	interval := float64(pollingIntervalFor(strategy.Strategy, pollingInterval, polls))
+	return time.Duration(interval + interval*strategy.Fraction*(2*rand.Float64()-1))
+}
+
+// pollingIntervalFor asks strategy for the next interval, falling back to pollingInterval when there is
+// no strategy or the strategy returns a non-positive interval.
+func pollingIntervalFor(strategy types.PollingStrategy, pollingInterval time.Duration, polls int) time.Duration {
+	if strategy == nil {
+		return pollingInterval
+	}
+	interval := strategy.NextPollingInterval(pollingInterval, polls)
+	if interval <= 0 {
+		return pollingInterval
+	}
+	return interval
+}
@@ -0,0 +1,42 @@
+package asyncassertion
+
+import "errors"
+
+// StopTryingError is returned or panicked by a polled function to make Eventually and Consistently
+// stop polling and fail immediately.
+type StopTryingError struct {
+	message string
+	err     error
+}
+
+func NewStopTryingError(message string) *StopTryingError {
+	return &StopTryingError{message: message}
+}
+
+// Wrap returns a copy of the StopTryingError that wraps err.
+func (s *StopTryingError) Wrap(err error) *StopTryingError {
+	return &StopTryingError{message: s.message, err: err}
+}
+
+func (s *StopTryingError) Error() string {
+	if s.err != nil {
+		return s.message + ": " + s.err.Error()
+	}
+	return s.message
+}
+
+func (s *StopTryingError) Unwrap() error {
+	return s.err
+}
+
+func asStopTryingError(actual interface{}) *StopTryingError {
+	err, ok := actual.(error)
+	if !ok {
+		return nil
+	}
+	var stopTrying *StopTryingError
+	if errors.As(err, &stopTrying) {
+		return stopTrying
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package asyncassertion
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// maxTimelineValueLength bounds how much of a polled value is shown on a single timeline line.
+const maxTimelineValueLength = 40
+
+type timelineEntry struct {
+	start   time.Duration
+	end     time.Duration
+	summary string
+	result  string
+	count   int
+}
+
+// timeline records every poll made by an AsyncAssertion so that failures can show how the value evolved.
+// Consecutive polls with the same summary and result are collapsed into a single entry.
+type timeline struct {
+	entries []timelineEntry
+}
+
+func (t *timeline) record(elapsed time.Duration, summary string, result string) {
+	if n := len(t.entries); n > 0 {
+		last := &t.entries[n-1]
+		if last.summary == summary && last.result == result {
+			last.end = elapsed
+			last.count++
+			return
+		}
+	}
+	t.entries = append(t.entries, timelineEntry{start: elapsed, end: elapsed, summary: summary, result: result, count: 1})
+}
+
+func (t *timeline) String() string {
+	lines := []string{"Timeline:"}
+	for _, entry := range t.entries {
+		if entry.count == 1 {
+			lines = append(lines, fmt.Sprintf("  t+%.2fs: %s (%s)", entry.start.Seconds(), entry.summary, entry.result))
+		} else {
+			lines = append(lines, fmt.Sprintf("  t+%.2fs..t+%.2fs: %s (%s) x%d", entry.start.Seconds(), entry.end.Seconds(), entry.summary, entry.result, entry.count))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// summarizeValue renders a one-line description of a polled value: collections are summarized by their length,
+// everything else by a truncated %v.
+func summarizeValue(value interface{}) string {
+	if value == nil {
+		return "nil"
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		if v.Kind() != reflect.Array && v.IsNil() {
+			return fmt.Sprintf("nil %T", value)
+		}
+		if v.Len() == 1 {
+			return "1 item"
+		}
+		return fmt.Sprintf("%d items", v.Len())
+	case reflect.String:
+		return truncateSummary(fmt.Sprintf("%q", value))
+	}
+	return truncateSummary(fmt.Sprintf("%v", value))
+}
+
+func truncateSummary(s string) string {
+	s = strings.Replace(s, "\n", " ", -1)
+	if len(s) > maxTimelineValueLength {
+		return s[:maxTimelineValueLength] + "..."
+	}
+	return s
+}
@@ -3,6 +3,9 @@ package defaults
 import (
 	"fmt"
 	"time"
+
+	"github.com/onsi/gomega/internal/asyncassertion"
+	"github.com/onsi/gomega/types"
 )
 
 func SetDurationFromEnv(getDurationFromEnv func(string) string, varSetter func(time.Duration), name string) {
@@ -20,3 +23,35 @@ func SetDurationFromEnv(getDurationFromEnv func(string) string, varSetter func(t
 
 	varSetter(duration)
 }
+
+// DefaultPollingMaxInterval caps exponential backoff polling configured from the environment
+// when no maximum interval is given.
+const DefaultPollingMaxInterval = time.Second
+
+// DefaultPollingJitter is the fraction by which jittered polling configured from the environment varies each interval.
+const DefaultPollingJitter = 0.25
+
+func SetPollingStrategyFromEnv(getFromEnv func(string) string, strategySetter func(types.PollingStrategy), strategyName string, maxIntervalName string) {
+	strategyFromEnv := getFromEnv(strategyName)
+
+	if len(strategyFromEnv) == 0 {
+		return
+	}
+
+	maxInterval := DefaultPollingMaxInterval
+	SetDurationFromEnv(getFromEnv, func(d time.Duration) { maxInterval = d }, maxIntervalName)
+	exponential := asyncassertion.ExponentialBackoffPollingStrategy{Factor: 2, MaxInterval: maxInterval}
+
+	switch strategyFromEnv {
+	case "fixed":
+		strategySetter(asyncassertion.FixedPollingStrategy{})
+	case "exponential":
+		strategySetter(exponential)
+	case "jittered":
+		strategySetter(asyncassertion.JitteredPollingStrategy{Strategy: asyncassertion.FixedPollingStrategy{}, Fraction: DefaultPollingJitter})
+	case "exponential-jittered":
+		strategySetter(asyncassertion.JitteredPollingStrategy{Strategy: exponential, Fraction: DefaultPollingJitter})
+	default:
+		panic(fmt.Sprintf("Expected one of fixed, exponential, jittered or exponential-jittered when using %s!  Got %q", strategyName, strategyFromEnv))
+	}
+}
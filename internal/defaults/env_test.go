@@ -6,7 +6,9 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 
+	"github.com/onsi/gomega/internal/asyncassertion"
 	d "github.com/onsi/gomega/internal/defaults"
+	"github.com/onsi/gomega/types"
 )
 
 var _ = Describe("Durations", func() {
@@ -79,3 +81,63 @@ var _ = Describe("Durations", func() {
 		})
 	})
 })
+
+var _ = Describe("Polling strategies", func() {
+	var (
+		strategy types.PollingStrategy
+		env      map[string]string
+
+		getFromEnv = func(name string) string {
+			return env[name]
+		}
+
+		setStrategy = func(s types.PollingStrategy) {
+			strategy = s
+		}
+	)
+
+	BeforeEach(func() {
+		strategy = nil
+		env = map[string]string{}
+	})
+
+	It("does not set the strategy when the environment does not have one", func() {
+		d.SetPollingStrategyFromEnv(getFromEnv, setStrategy, "MY_STRATEGY", "MY_MAX_INTERVAL")
+		Expect(strategy).To(BeNil())
+	})
+
+	It("sets a fixed strategy", func() {
+		env["MY_STRATEGY"] = "fixed"
+		d.SetPollingStrategyFromEnv(getFromEnv, setStrategy, "MY_STRATEGY", "MY_MAX_INTERVAL")
+		Expect(strategy).To(Equal(asyncassertion.FixedPollingStrategy{}))
+	})
+
+	It("sets an exponential strategy, capped at the default max interval", func() {
+		env["MY_STRATEGY"] = "exponential"
+		d.SetPollingStrategyFromEnv(getFromEnv, setStrategy, "MY_STRATEGY", "MY_MAX_INTERVAL")
+		Expect(strategy).To(Equal(asyncassertion.ExponentialBackoffPollingStrategy{Factor: 2, MaxInterval: d.DefaultPollingMaxInterval}))
+	})
+
+	It("sets an exponential strategy with the max interval from the environment", func() {
+		env["MY_STRATEGY"] = "exponential-jittered"
+		env["MY_MAX_INTERVAL"] = "3s"
+		d.SetPollingStrategyFromEnv(getFromEnv, setStrategy, "MY_STRATEGY", "MY_MAX_INTERVAL")
+		Expect(strategy).To(Equal(asyncassertion.JitteredPollingStrategy{
+			Strategy: asyncassertion.ExponentialBackoffPollingStrategy{Factor: 2, MaxInterval: 3 * time.Second},
+			Fraction: d.DefaultPollingJitter,
+		}))
+	})
+
+	It("sets a jittered strategy", func() {
+		env["MY_STRATEGY"] = "jittered"
+		d.SetPollingStrategyFromEnv(getFromEnv, setStrategy, "MY_STRATEGY", "MY_MAX_INTERVAL")
+		Expect(strategy).To(Equal(asyncassertion.JitteredPollingStrategy{Strategy: asyncassertion.FixedPollingStrategy{}, Fraction: d.DefaultPollingJitter}))
+	})
+
+	It("panics with a helpful error message when the strategy is not valid", func() {
+		env["MY_STRATEGY"] = "quadratic"
+		Expect(func() {
+			d.SetPollingStrategyFromEnv(getFromEnv, setStrategy, "MY_STRATEGY", "MY_MAX_INTERVAL")
+		}).To(PanicWith(MatchRegexp("Expected one of fixed, exponential, jittered or exponential-jittered when using MY_STRATEGY")))
+	})
+})
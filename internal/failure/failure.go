@@ -0,0 +1,126 @@
+/*
+Package failure builds the structured types.Failure values that assertions report to a types.GomegaFailureHandler,
+and the types.AssertionResult values they report to a types.AssertionReporter.
+*/
+package failure
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/onsi/gomega/types"
+)
+
+//New builds the Failure for an assertion.  description is the formatted optional description, matcher may be nil.
+//
+//skip is the callerSkip that accompanies the failure and, like a callerSkip handed to a fail handler by New's caller,
+//identifies the line the failure is located at.
+func New(message string, description string, matcher types.GomegaMatcher, actual interface{}, negated bool, skip int) types.Failure {
+	return types.Failure{
+		Message:     message,
+		Description: strings.TrimSuffix(description, "\n"),
+		Actual:      actual,
+		Expected:    expectedValue(matcher),
+		Matcher:     matcher,
+		Negated:     negated,
+		Location:    location(skip + 1),
+	}
+}
+
+//MarkHandlerPanic is deferred by an assertion just before it hands a failure to its fail handler.  If the handler
+//panics, as Ginkgo's Fail does, MarkHandlerPanic raises the panic again from its own frame, so that
+//RaisedByFailHandler can tell it apart from any other panic.
+func MarkHandlerPanic() {
+	if e := recover(); e != nil {
+		panic(e)
+	}
+}
+
+var markHandlerPanicFunction = runtime.FuncForPC(reflect.ValueOf(MarkHandlerPanic).Pointer()).Name()
+
+//RaisedByFailHandler is true when the panic the calling deferred function is recovering from was raised by a fail
+//handler and marked by MarkHandlerPanic.  It must be called from the deferred function itself, while the panic's
+//frames are still on the goroutine's stack.
+func RaisedByFailHandler() bool {
+	pcs := make([]uintptr, 64)
+	for {
+		n := runtime.Callers(2, pcs)
+		if n < len(pcs) {
+			pcs = pcs[:n]
+			break
+		}
+		pcs = make([]uintptr, 2*len(pcs))
+	}
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if frame.Function == markHandlerPanicFunction {
+			return true
+		}
+		if !more {
+			return false
+		}
+	}
+}
+
+//ReportPass tells the wrapper's Reporter, if it has one, that an assertion passed.  skip is as for New.
+func ReportPass(wrapper *types.GomegaFailWrapper, assertion string, matcher types.GomegaMatcher, negated bool, duration time.Duration, skip int) {
+	if wrapper.Reporter == nil {
+		return
+	}
+	wrapper.Reporter.ReportAssertion(types.AssertionResult{
+		Assertion: assertion,
+		Passed:    true,
+		Matcher:   matcher,
+		Negated:   negated,
+		Location:  location(skip + 1),
+		Duration:  duration,
+	})
+}
+
+//ReportFailure tells the wrapper's Reporter, if it has one, that an assertion failed.
+func ReportFailure(wrapper *types.GomegaFailWrapper, assertion string, failure types.Failure, duration time.Duration) {
+	if wrapper.Reporter == nil {
+		return
+	}
+	wrapper.Reporter.ReportAssertion(types.AssertionResult{
+		Assertion:   assertion,
+		Message:     failure.Message,
+		Description: failure.Description,
+		Matcher:     failure.Matcher,
+		Negated:     failure.Negated,
+		Location:    failure.Location,
+		Duration:    duration,
+	})
+}
+
+func location(skip int) types.FailureLocation {
+	_, file, line, ok := runtime.Caller(skip + 1)
+	if !ok {
+		return types.FailureLocation{}
+	}
+	return types.FailureLocation{FileName: file, LineNumber: line}
+}
+
+func expectedValue(matcher types.GomegaMatcher) interface{} {
+	if matcher == nil {
+		return nil
+	}
+	value := reflect.ValueOf(matcher)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	field, ok := value.Type().FieldByName("Expected")
+	if !ok || field.PkgPath != "" {
+		return nil
+	}
+	return value.FieldByIndex(field.Index).Interface()
+}
@@ -0,0 +1,35 @@
+package returnvaluesmatcher
+
+import "github.com/onsi/gomega/types"
+
+/*
+GomegaMatchers that also match the ReturnValuesMatcher interface can ask to be handed all of a polled function's
+return values, as a []interface{}, rather than only the first.
+
+The ReturnValues matcher does, and matchers that wrap another matcher, such as Not and DescribedAs, pass the question
+on to the matcher they wrap.
+*/
+type ReturnValuesMatcher interface {
+	MatchesReturnValues() bool
+}
+
+func MatchesReturnValues(matcher types.GomegaMatcher) bool {
+	returnValuesMatcher, ok := matcher.(ReturnValuesMatcher)
+	if !ok {
+		return false
+	}
+
+	return returnValuesMatcher.MatchesReturnValues()
+}
+
+//AllMatchReturnValues is true when there are matchers and every one of them matches return values.  Matchers that
+//combine several matchers, such as And, hand all of them the same value, so they can only match return values when
+//every one of their matchers does.
+func AllMatchReturnValues(matchers []types.GomegaMatcher) bool {
+	for _, matcher := range matchers {
+		if !MatchesReturnValues(matcher) {
+			return false
+		}
+	}
+	return len(matchers) > 0
+}
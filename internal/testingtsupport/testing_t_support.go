@@ -1,9 +1,11 @@
 package testingtsupport
 
 import (
+	"fmt"
 	"regexp"
 	"runtime/debug"
 	"strings"
+	"sync/atomic"
 
 	"github.com/onsi/gomega/types"
 )
@@ -24,7 +26,17 @@ func BuildTestingTGomegaFailWrapper(t gomegaTestingT) *types.GomegaFailWrapper {
 		tWithHelper = EmptyTWithHelper{}
 	}
 
+	// once the test has completed a t.Fatalf crashes the test binary with a message that does not
+	// say where the failure came from, so we panic with one that does
+	var completed int32
+	if tWithCleanup, ok := t.(interface{ Cleanup(func()) }); ok {
+		tWithCleanup.Cleanup(func() { atomic.StoreInt32(&completed, 1) })
+	}
+
 	fail := func(message string, callerSkip ...int) {
+		if atomic.LoadInt32(&completed) == 1 {
+			panic(lateFailureMessage(t, message))
+		}
 		if hasHelper {
 			tWithHelper.Helper()
 			t.Fatalf("\n%s", message)
@@ -44,6 +56,14 @@ func BuildTestingTGomegaFailWrapper(t gomegaTestingT) *types.GomegaFailWrapper {
 	}
 }
 
+func lateFailureMessage(t gomegaTestingT, message string) string {
+	name := "the test"
+	if tWithName, ok := t.(interface{ Name() string }); ok {
+		name = tWithName.Name()
+	}
+	return fmt.Sprintf("Gomega assertion failed after %s completed.  This usually means a goroutine outlived its test; use WithT.Go to start goroutines that make assertions.\n\n%s", name, message)
+}
+
 func pruneStack(fullStackTrace string, skip int) string {
 	stack := strings.Split(fullStackTrace, "\n")[1:]
 	if len(stack) > 2*skip {
@@ -1,10 +1,13 @@
 package testingtsupport_test
 
 import (
+	"errors"
 	"regexp"
+	"runtime"
 	"time"
 
 	"github.com/onsi/gomega/internal/testingtsupport"
+	"github.com/onsi/gomega/types"
 
 	. "github.com/onsi/gomega"
 
@@ -90,3 +93,289 @@ func TestGomegaWithTWithHelper(t *testing.T) {
 	g.Expect(f.LastFatal).To(ContainSubstring("<string>: foo3"))
 	g.Expect(f.HelperCount).To(BeNumerically(">", 0))
 }
+
+func TestGomegaWithTConfiguredWithDefaults(t *testing.T) {
+	// the timeouts are far enough apart that a subtest timing out after the other's timeout cannot pass
+	for _, timeout := range []time.Duration{20 * time.Millisecond, 2 * time.Second} {
+		timeout := timeout
+		t.Run(timeout.String(), func(t *testing.T) {
+			t.Parallel()
+			g := NewGomegaWithT(t)
+
+			f := &FakeTWithHelper{}
+			testG := NewGomegaWithT(f).ConfigureWithDefaults(WithDefaultEventuallyTimeout(timeout), WithDefaultEventuallyPollingInterval(5*time.Millisecond))
+
+			start := time.Now()
+			testG.Eventually(func() int { return 0 }).Should(Equal(1))
+			g.Expect(time.Since(start)).To(BeNumerically(">=", timeout))
+			g.Expect(time.Since(start)).To(BeNumerically("<", timeout+time.Second))
+			g.Expect(f.LastFatal).To(ContainSubstring("Timed out after"))
+		})
+	}
+}
+
+func TestGomegaWithTFollowsGlobalDefaultsUntilConfigured(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	f := &FakeTWithHelper{}
+	testG := NewGomegaWithT(f)
+
+	SetDefaultConsistentlyDuration(300 * time.Millisecond)
+	defer SetDefaultConsistentlyDuration(100 * time.Millisecond)
+
+	start := time.Now()
+	testG.Consistently(func() int { return 0 }).Should(Equal(0))
+	g.Expect(time.Since(start)).To(BeNumerically(">=", 300*time.Millisecond))
+
+	testG.ConfigureWithDefaults()
+	SetDefaultConsistentlyDuration(10 * time.Second)
+
+	start = time.Now()
+	testG.Consistently(func() int { return 0 }).Should(Equal(0))
+	g.Expect(time.Since(start)).To(BeNumerically("<", 5*time.Second), "a configured WithT should not see later changes to the global defaults")
+}
+
+func TestNewGomega(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	failures := []string{}
+	testG := NewGomega(func(message string, callerSkip ...int) {
+		failures = append(failures, message)
+	}, WithDefaultConsistentlyDuration(10*time.Millisecond), WithDefaultAsyncTimeline(true))
+
+	start := time.Now()
+	testG.Consistently(func() string { return "foo" }).Should(Equal("foo"))
+	g.Expect(time.Since(start)).To(BeNumerically("<", 50*time.Millisecond))
+	g.Expect(failures).To(BeEmpty())
+
+	testG.Expect("foo").To(Equal("bar"))
+	testG.Consistently(func() string { return "foo" }).Should(Equal("bar"))
+
+	g.Expect(failures).To(HaveLen(2))
+	g.Expect(failures[0]).To(ContainSubstring("<string>: foo"))
+	g.Expect(failures[1]).To(ContainSubstring("Timeline:"))
+}
+
+func TestNewGomegaWithFailureHandler(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	failures := []types.Failure{}
+	testG := NewGomegaWithFailureHandler(func(failure types.Failure, callerSkip ...int) {
+		failures = append(failures, failure)
+	}, WithDefaultEventuallyTimeout(10*time.Millisecond))
+
+	_, file, line, _ := runtime.Caller(0)
+	testG.Expect("foo").To(Equal("bar"), "checking %s", "foo")
+	testG.Eventually(func() int { return 1 }).ShouldNot(Equal(1))
+
+	g.Expect(failures).To(HaveLen(2))
+	g.Expect(failures[0].Message).To(Equal("checking foo\nExpected\n    <string>: foo\nto equal\n    <string>: bar"))
+	g.Expect(failures[0].Description).To(Equal("checking foo"))
+	g.Expect(failures[0].Actual).To(Equal("foo"))
+	g.Expect(failures[0].Expected).To(Equal("bar"))
+	g.Expect(failures[0].Matcher).To(BeAssignableToTypeOf(Equal("bar")))
+	g.Expect(failures[0].Negated).To(BeFalse())
+	g.Expect(failures[0].Location).To(Equal(types.FailureLocation{FileName: file, LineNumber: line + 1}))
+
+	g.Expect(failures[1].Message).To(HavePrefix("Timed out after"))
+	g.Expect(failures[1].Actual).To(Equal(1))
+	g.Expect(failures[1].Expected).To(Equal(1))
+	g.Expect(failures[1].Negated).To(BeTrue())
+	g.Expect(failures[1].Location).To(Equal(types.FailureLocation{FileName: file, LineNumber: line + 2}))
+}
+
+func TestCheck(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	g.Expect(Check("foo").To(Equal("foo"))).To(Succeed())
+	g.Expect(Check("foo").ShouldNot(Equal("bar"))).To(Succeed())
+
+	_, file, line, _ := runtime.Caller(0)
+	err := Check("foo").To(Equal("bar"), "checking %s", "foo")
+	g.Expect(err).To(MatchError("checking foo\nExpected\n    <string>: foo\nto equal\n    <string>: bar"))
+	var assertionErr *AssertionError
+	g.Expect(errors.As(err, &assertionErr)).To(BeTrue())
+	g.Expect(assertionErr.Failure.Actual).To(Equal("foo"))
+	g.Expect(assertionErr.Failure.Expected).To(Equal("bar"))
+	g.Expect(assertionErr.Failure.Location).To(Equal(types.FailureLocation{FileName: file, LineNumber: line + 1}))
+
+	g.Expect(Check("foo", errors.New("boom")).To(Equal("foo"))).To(MatchError(ContainSubstring("Unexpected non-nil/non-zero extra argument at index 1")))
+}
+
+func TestErrorGomega(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	eg := NewErrorGomega(WithDefaultEventuallyTimeout(50*time.Millisecond), WithDefaultEventuallyPollingInterval(5*time.Millisecond))
+	g.Expect(eg.Expect(3).NotTo(Equal(4))).To(Succeed())
+	g.Expect(eg.Expect(3).ToNot(Equal(3))).To(MatchError(ContainSubstring("not to equal")))
+
+	counter := 0
+	g.Expect(eg.Eventually(func() int {
+		counter++
+		return counter
+	}).Should(Equal(3))).To(Succeed())
+
+	_, file, line, _ := runtime.Caller(0)
+	err := eg.Eventually(func() int { return 0 }).WithTimeout(20 * time.Millisecond).Should(Equal(1))
+	g.Expect(err).To(MatchError(HavePrefix("Timed out after")))
+	var assertionErr *AssertionError
+	g.Expect(errors.As(err, &assertionErr)).To(BeTrue())
+	g.Expect(assertionErr.Failure.Location).To(Equal(types.FailureLocation{FileName: file, LineNumber: line + 1}))
+
+	g.Expect(eg.Consistently(func() int { return 0 }, 20*time.Millisecond).ShouldNot(Equal(1))).To(Succeed())
+	g.Expect(eg.Consistently(func() int { return 0 }, 20*time.Millisecond).Should(Equal(1))).To(MatchError(HavePrefix("Failed after")))
+}
+
+func TestSoftGomega(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	f := &FakeTWithoutHelper{}
+	soft := NewSoftGomega(f, WithDefaultEventuallyTimeout(20*time.Millisecond))
+
+	soft.Expect("foo").To(Equal("foo"))
+	soft.Expect("foo").To(Equal("bar"))
+	soft.Expect(1).To(BeNumerically(">", 2))
+	soft.Eventually(func() int { return 0 }).Should(Equal(1))
+	g.Expect(f.LastFatal).To(BeZero(), "failures should not be reported until Verify is called")
+	g.Expect(soft.Failures()).To(HaveLen(3))
+	g.Expect(soft.Failures()[0]).To(MatchRegexp(`^.*testing_t_support_test\.go:\d+\n`), "failures should record the location of the assertion")
+
+	soft.Verify()
+	g.Expect(f.LastFatal).To(ContainSubstring("3 assertions failed:"))
+	g.Expect(f.LastFatal).To(ContainSubstring("1) "))
+	g.Expect(f.LastFatal).To(ContainSubstring("<string>: foo"))
+	g.Expect(f.LastFatal).To(ContainSubstring("2) "))
+	g.Expect(f.LastFatal).To(ContainSubstring("to be >"))
+	g.Expect(f.LastFatal).To(ContainSubstring("3) "))
+	g.Expect(f.LastFatal).To(ContainSubstring("Timed out after"))
+	g.Expect(soft.Failures()).To(BeEmpty())
+
+	f.LastFatal = ""
+	soft.Verify()
+	g.Expect(f.LastFatal).To(BeZero())
+
+	soft.Expect(true).To(BeFalse())
+	soft.Verify()
+	g.Expect(f.LastFatal).To(ContainSubstring("1 assertion failed:"))
+}
+
+func TestSoftGomegaVerifiesWhenTheTestFinishes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	f := &FakeTWithCleanup{}
+	soft := NewSoftGomega(f)
+	g.Expect(f.cleanups).To(HaveLen(2))
+
+	soft.Expect("foo").To(Equal("bar"))
+	g.Expect(f.LastFatal).To(BeZero())
+	f.runCleanups()
+	g.Expect(f.LastFatal).To(ContainSubstring("1 assertion failed:"), "a forgotten Verify should not lose the failures")
+	g.Expect(f.LastFatal).To(ContainSubstring("<string>: foo"))
+	g.Expect(f.LastFatal).NotTo(ContainSubstring("after TestFake completed"))
+
+	f = &FakeTWithCleanup{}
+	soft = NewSoftGomega(f)
+	soft.Expect("foo").To(Equal("bar"))
+	soft.Verify()
+	soft.Verify()
+	g.Expect(f.LastFatal).To(ContainSubstring("1 assertion failed:"))
+	g.Expect(f.cleanups).To(HaveLen(2), "Verify should not register further cleanups")
+
+	f.LastFatal = ""
+	f.runCleanups()
+	g.Expect(f.LastFatal).To(BeZero(), "failures already reported by Verify should not be reported again")
+}
+
+type FakeTWithCleanup struct {
+	FakeTWithHelper
+	cleanups []func()
+}
+
+func (f *FakeTWithCleanup) Helper() {}
+
+func (f *FakeTWithCleanup) Name() string {
+	return "TestFake"
+}
+
+func (f *FakeTWithCleanup) Cleanup(cleanup func()) {
+	f.cleanups = append(f.cleanups, cleanup)
+}
+
+func (f *FakeTWithCleanup) runCleanups() {
+	for i := len(f.cleanups) - 1; i >= 0; i-- {
+		f.cleanups[i]()
+	}
+	f.cleanups = nil
+}
+
+func TestGo(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	f := &FakeTWithCleanup{}
+	testG := NewGomegaWithT(f)
+
+	testG.Go(func(g Gomega) {
+		g.Expect("foo").To(Equal("foo"))
+	})
+	testG.Wait()
+	g.Expect(f.LastFatal).To(BeZero())
+
+	testG.Go(func(g Gomega) {
+		g.Expect("foo").To(Equal("bar"))
+		panic("not reached")
+	})
+	testG.Go(func(g Gomega) {
+		panic("boom")
+	})
+	testG.Wait()
+	g.Expect(f.LastFatal).To(ContainSubstring("Assertion in goroutine at"))
+	g.Expect(f.LastFatal).To(ContainSubstring("<string>: foo"))
+	g.Expect(f.LastFatal).NotTo(ContainSubstring("not reached"))
+	g.Expect(f.LastFatal).To(ContainSubstring("Goroutine panicked:\nboom"))
+	g.Expect(f.LastFatal).To(ContainSubstring("In goroutine started at "))
+}
+
+func TestGoWaitsWhenTheTestFinishes(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	f := &FakeTWithCleanup{}
+	testG := NewGomegaWithT(f)
+
+	release := make(chan bool)
+	_, file, line, _ := runtime.Caller(0)
+	testG.Go(func(g Gomega) {
+		<-release
+		g.Expect(1).To(Equal(2))
+	})
+	g.Expect(f.cleanups).To(HaveLen(2))
+
+	close(release)
+	f.runCleanups()
+	g.Expect(f.LastFatal).To(ContainSubstring("<int>: 1"))
+	g.Expect(f.LastFatal).To(ContainSubstring(fmt.Sprintf("In goroutine started at %s:%d:\n", file, line+1)), "the failure should name the line Go was called from")
+}
+
+func TestGoWithTestingT(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	polls := 0
+	g.Go(func(g Gomega) {
+		g.Eventually(func() int {
+			polls++
+			return polls
+		}).Should(Equal(3))
+	})
+}
+
+func TestFailingAfterTheTestCompleted(t *testing.T) {
+	g := NewGomegaWithT(t)
+
+	f := &FakeTWithCleanup{}
+	testG := NewGomegaWithT(f)
+	f.runCleanups()
+
+	g.Expect(func() {
+		testG.Expect("foo").To(Equal("bar"))
+	}).To(PanicWith(HavePrefix("Gomega assertion failed after TestFake completed.")))
+	g.Expect(f.LastFatal).To(BeZero())
+}
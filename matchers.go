@@ -10,6 +10,8 @@ import (
 //Equal uses reflect.DeepEqual to compare actual with expected.  Equal is strict about
 //types when performing comparisons.
 //It is an error for both actual and expected to be nil.  Use BeNil() instead.
+//When actual and expected are structs, maps, slices or arrays of the same type, the failure message lists only the
+//paths at which they differ (see format.Diff).
 func Equal(expected interface{}) types.GomegaMatcher {
 	return &matchers.EqualMatcher{
 		Expected: expected,
@@ -427,6 +429,17 @@ func HaveHTTPStatus(expected interface{}) types.GomegaMatcher {
 	return &matchers.HaveHTTPStatusMatcher{Expected: expected}
 }
 
+//ReturnValues succeeds if each of a function's return values satisfies the matcher in the same position.
+//It is used with ExpectReturns, and with Eventually and Consistently polling a function with several return values:
+//  Eventually(client.Fetch).Should(ReturnValues(Equal(3), HavePrefix("x"), Succeed()))
+//
+//The failure message names the index of the first return value that did not match.  ReturnValues can be wrapped in
+//Not, And, Or and DescribedAs, and Eventually still hands it every return value:
+//  Eventually(client.Fetch).Should(Not(ReturnValues(BeZero(), BeEmpty(), Succeed())))
+func ReturnValues(ms ...types.GomegaMatcher) types.GomegaMatcher {
+	return &matchers.ReturnValuesMatcher{Matchers: ms}
+}
+
 //And succeeds only if all of the given matchers succeed.
 //The matchers are tried in order, and will fail-fast if one doesn't succeed.
 //  Expect("hi").To(And(HaveLen(2), Equal("hi"))
@@ -457,6 +470,20 @@ func SatisfyAny(matchers ...types.GomegaMatcher) types.GomegaMatcher {
 	return Or(matchers...)
 }
 
+//DescribedAs labels a matcher, which is useful for naming the parts of a composite matcher:
+//  var validUser = And(
+//      DescribedAs("named", WithTransform(User.Name, Not(BeEmpty()))),
+//      DescribedAs("adult", WithTransform(User.Age, BeNumerically(">=", 18))),
+//  )
+//  Expect(user).To(DescribedAs("valid user", validUser))
+//
+//When And() and Or() are nested, or contain described matchers, their failure messages are rendered as an indented
+//tree that shows which branches passed, which failed and which were not evaluated.  Described matchers appear in the
+//tree under their label.
+func DescribedAs(label string, matcher types.GomegaMatcher) types.GomegaMatcher {
+	return &matchers.DescribedAsMatcher{Label: label, Matcher: matcher}
+}
+
 //Not negates the given matcher; it succeeds if the given matcher fails.
 //  Expect(1).To(Not(Equal(2))
 //
@@ -2,9 +2,11 @@ package matchers
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/onsi/gomega/format"
 	"github.com/onsi/gomega/internal/oraclematcher"
+	"github.com/onsi/gomega/internal/returnvaluesmatcher"
 	"github.com/onsi/gomega/types"
 )
 
@@ -13,21 +15,28 @@ type AndMatcher struct {
 
 	// state
 	firstFailedMatcher types.GomegaMatcher
+	results            []matchResult
 }
 
 func (m *AndMatcher) Match(actual interface{}) (success bool, err error) {
 	m.firstFailedMatcher = nil
-	for _, matcher := range m.Matchers {
+	m.results = make([]matchResult, len(m.Matchers))
+	for i, matcher := range m.Matchers {
 		success, err := matcher.Match(actual)
 		if !success || err != nil {
 			m.firstFailedMatcher = matcher
+			m.results[i] = didNotMatch
 			return false, err
 		}
+		m.results[i] = matched
 	}
 	return true, nil
 }
 
 func (m *AndMatcher) FailureMessage(actual interface{}) (message string) {
+	if hasTreeMatcher(m.Matchers) {
+		return treeFailureMessage(m, actual)
+	}
 	return m.firstFailedMatcher.FailureMessage(actual)
 }
 
@@ -36,6 +45,16 @@ func (m *AndMatcher) NegatedFailureMessage(actual interface{}) (message string)
 	return format.Message(actual, fmt.Sprintf("To not satisfy all of these matchers: %s", m.Matchers))
 }
 
+func (m *AndMatcher) treeHeadline(actual interface{}) string {
+	return "to satisfy all of:"
+}
+
+func (m *AndMatcher) writeTreeChildren(buffer *strings.Builder, actual interface{}, indentation string) {
+	for i, matcher := range m.Matchers {
+		writeTreeNode(buffer, matcher, m.results[i], actual, indentation)
+	}
+}
+
 func (m *AndMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
 	/*
 		Example with 3 matchers: A, B, C
@@ -61,3 +80,7 @@ func (m *AndMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
 	// one of the matchers failed.. it must be able to change in order to affect the result
 	return oraclematcher.MatchMayChangeInTheFuture(m.firstFailedMatcher, actual)
 }
+
+func (m *AndMatcher) MatchesReturnValues() bool {
+	return returnvaluesmatcher.AllMatchReturnValues(m.Matchers)
+}
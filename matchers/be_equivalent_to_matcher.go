@@ -18,19 +18,27 @@ func (matcher *BeEquivalentToMatcher) Match(actual interface{}) (success bool, e
 		return false, fmt.Errorf("Both actual and expected must not be nil.")
 	}
 
-	convertedActual := actual
-
-	if actual != nil && matcher.Expected != nil && reflect.TypeOf(actual).ConvertibleTo(reflect.TypeOf(matcher.Expected)) {
-		convertedActual = reflect.ValueOf(actual).Convert(reflect.TypeOf(matcher.Expected)).Interface()
-	}
-
-	return reflect.DeepEqual(convertedActual, matcher.Expected), nil
+	return reflect.DeepEqual(matcher.convert(actual), matcher.Expected), nil
 }
 
 func (matcher *BeEquivalentToMatcher) FailureMessage(actual interface{}) (message string) {
-	return format.Message(actual, "to be equivalent to", matcher.Expected)
+	return format.MessageWithDifferences(actual, "to be equivalent to", matcher.Expected, matcher.Differences(actual))
 }
 
 func (matcher *BeEquivalentToMatcher) NegatedFailureMessage(actual interface{}) (message string) {
 	return format.Message(actual, "not to be equivalent to", matcher.Expected)
 }
+
+//Differences returns the paths at which actual, converted to the expected value's type as Match does, differs from
+//the expected value.  See format.Diff.
+func (matcher *BeEquivalentToMatcher) Differences(actual interface{}) []format.Difference {
+	return format.Diff(matcher.convert(actual), matcher.Expected)
+}
+
+//convert converts actual to the expected value's type, if it can be
+func (matcher *BeEquivalentToMatcher) convert(actual interface{}) interface{} {
+	if actual != nil && matcher.Expected != nil && reflect.TypeOf(actual).ConvertibleTo(reflect.TypeOf(matcher.Expected)) {
+		return reflect.ValueOf(actual).Convert(reflect.TypeOf(matcher.Expected)).Interface()
+	}
+	return actual
+}
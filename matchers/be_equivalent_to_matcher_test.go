@@ -47,4 +47,28 @@ var _ = Describe("BeEquivalentTo", func() {
 			Expect(5).ShouldNot(BeEquivalentTo(5.1))
 		})
 	})
+
+	Describe("failure messages", func() {
+		It("shows only the paths at which two composite values of the same type differ", func() {
+			failureMessage := BeEquivalentTo(map[string]int{"a": 1, "b": 2}).FailureMessage(map[string]int{"a": 1, "b": 3})
+			Expect(failureMessage).To(HaveSuffix("but they differ at (actual != expected):\n    [\"b\"]: 3 != 2"))
+		})
+
+		It("converts actual to the expected type before diffing, and names actual's own type", func() {
+			type scores map[string]int
+			failureMessage := BeEquivalentTo(map[string]int{"a": 1, "b": 2}).FailureMessage(scores{"a": 1, "b": 3})
+			Expect(failureMessage).To(Equal("Expected\n    <matchers_test.scores | len:2>\nto be equivalent to\n    <map[string]int | len:2>\nbut they differ at (actual != expected):\n    [\"b\"]: 3 != 2"))
+
+			type point struct{ X, Y int }
+			type coordinates struct{ X, Y int }
+			failureMessage = BeEquivalentTo(point{1, 2}).FailureMessage(coordinates{1, 3})
+			Expect(failureMessage).To(HavePrefix("Expected\n    <matchers_test.coordinates>\nto be equivalent to\n    <matchers_test.point>\n"))
+			Expect(failureMessage).To(HaveSuffix("but they differ at (actual != expected):\n    .Y: 3 != 2"))
+		})
+
+		It("shows both values in full when actual cannot be converted to the expected type", func() {
+			failureMessage := BeEquivalentTo([]int{1}).FailureMessage([]int64{2})
+			Expect(failureMessage).To(Equal("Expected\n    <[]int64 | len:1, cap:1>: [2]\nto be equivalent to\n    <[]int | len:1, cap:1>: [1]"))
+		})
+	})
 })
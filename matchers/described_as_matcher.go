@@ -0,0 +1,63 @@
+package matchers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/internal/oraclematcher"
+	"github.com/onsi/gomega/internal/returnvaluesmatcher"
+	"github.com/onsi/gomega/types"
+)
+
+type DescribedAsMatcher struct {
+	Label   string
+	Matcher types.GomegaMatcher
+}
+
+func (m *DescribedAsMatcher) Match(actual interface{}) (success bool, err error) {
+	success, err = m.Matcher.Match(actual)
+	if err != nil {
+		return false, fmt.Errorf("%s: %s", m.Label, err.Error())
+	}
+	return success, nil
+}
+
+func (m *DescribedAsMatcher) FailureMessage(actual interface{}) (message string) {
+	if _, ok := m.Matcher.(treeMatcher); !ok {
+		if _, ok := leafDescription(m.Matcher, actual); !ok {
+			return fmt.Sprintf("%s:\n%s", m.Label, m.Matcher.FailureMessage(actual))
+		}
+	}
+	return treeFailureMessage(m, actual)
+}
+
+func (m *DescribedAsMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return fmt.Sprintf("%s:\n%s", m.Label, m.Matcher.NegatedFailureMessage(actual))
+}
+
+func (m *DescribedAsMatcher) treeHeadline(actual interface{}) string {
+	if tree, ok := m.Matcher.(treeMatcher); ok {
+		return m.Label + ": " + tree.treeHeadline(actual)
+	}
+	description, ok := leafDescription(m.Matcher, actual)
+	if !ok {
+		return m.Label + ":"
+	}
+	return m.Label + ": " + description
+}
+
+func (m *DescribedAsMatcher) writeTreeChildren(buffer *strings.Builder, actual interface{}, indentation string) {
+	if tree, ok := m.Matcher.(treeMatcher); ok {
+		tree.writeTreeChildren(buffer, actual, indentation)
+	} else if message, ok := leafDescription(m.Matcher, actual); !ok {
+		writeLeafMessage(buffer, message, indentation)
+	}
+}
+
+func (m *DescribedAsMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
+	return oraclematcher.MatchMayChangeInTheFuture(m.Matcher, actual)
+}
+
+func (m *DescribedAsMatcher) MatchesReturnValues() bool {
+	return returnvaluesmatcher.MatchesReturnValues(m.Matcher)
+}
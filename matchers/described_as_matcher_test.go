@@ -0,0 +1,98 @@
+package matchers_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/internal/fakematcher"
+)
+
+var _ = Describe("DescribedAsMatcher", func() {
+	It("matches when the described matcher matches", func() {
+		Expect(input).To(DescribedAs("greeting", true1))
+		Expect(input).ToNot(DescribedAs("greeting", false1))
+	})
+
+	It("labels errors", func() {
+		success, err := DescribedAs("a number", BeNumerically(">", 1)).Match(input)
+		Expect(success).To(BeFalse())
+		Expect(err).To(MatchError(HavePrefix("a number: ")))
+	})
+
+	Context("failure messages", func() {
+		When("match fails", func() {
+			It("labels the described matcher's message", func() {
+				verifyFailureMessage(DescribedAs("greeting", false2), input, "greeting: to equal\n    <string>: hip")
+			})
+
+			It("renders a described composite as a tree", func() {
+				verifyFailureMessage(DescribedAs("greeting", And(true1, false2, false3)), input,
+					"greeting: to satisfy all of:\n"+
+						"  ✓ to have length 2\n"+
+						"  ✗ to equal\n"+
+						"        <string>: hip\n"+
+						"  - not evaluated: MatchRegexpMatcher")
+			})
+
+			It("falls back to the full message when it cannot be shortened", func() {
+				matcher := &fakematcher.FakeMatcher{MatchesToReturn: false}
+				m := DescribedAs("greeting", matcher)
+				Expect(m.Match(input)).To(BeFalse())
+				Expect(m.FailureMessage(input)).To(Equal("greeting:\npositive: " + input))
+			})
+		})
+
+		When("match succeeds, but expected it to fail", func() {
+			It("labels the described matcher's negated message", func() {
+				m := DescribedAs("greeting", true2)
+				Expect(m.Match(input)).To(BeTrue())
+				Expect(m.NegatedFailureMessage(input)).To(Equal("greeting:\nExpected\n    <string>: hi\nnot to equal\n    <string>: hi"))
+			})
+		})
+	})
+
+	Context("nested composite matchers", func() {
+		It("renders And and Or as an indented tree", func() {
+			verifyFailureMessage(And(true1, DescribedAs("polite", Or(false2, And(true2, false3))), false1), input,
+				"to satisfy all of:\n"+
+					"  ✓ to have length 2\n"+
+					"  ✗ polite: to satisfy any of:\n"+
+					"      ✗ to equal\n"+
+					"            <string>: hip\n"+
+					"      ✗ to satisfy all of:\n"+
+					"          ✓ to equal\n"+
+					"                <string>: hi\n"+
+					"          ✗ to match regular expression\n"+
+					"                <string>: hope\n"+
+					"  - not evaluated: HaveLenMatcher")
+		})
+
+		It("renders messages that cannot be shortened as a block under their node", func() {
+			name := func(string) string { return "bob" }
+			verifyFailureMessage(And(DescribedAs("named", WithTransform(name, Not(BeEmpty()))), WithTransform(name, Not(BeEmpty())), false2), input,
+				"to satisfy all of:\n"+
+					"  ✓ named:\n"+
+					"      Expected\n"+
+					"          <string>: bob\n"+
+					"      not to be empty\n"+
+					"  ✓ WithTransformMatcher:\n"+
+					"      Expected\n"+
+					"          <string>: bob\n"+
+					"      not to be empty\n"+
+					"  ✗ to equal\n"+
+					"        <string>: hip")
+		})
+
+		It("keeps the flat message when nothing is nested", func() {
+			verifyFailureMessage(Or(false1, false2), input, "To satisfy at least one of these matchers: [%!s(*matchers.HaveLenMatcher=&{1}) %!s(*matchers.EqualMatcher=&{hip})]")
+		})
+
+		It("labels errors from nested described matchers", func() {
+			m := Or(DescribedAs("numeric", BeNumerically(">", 1)), true1)
+			success, err := m.Match(input)
+			Expect(success).To(BeFalse())
+			Expect(err).To(MatchError(errors.New("numeric: Expected a number.  Got:\n    <string>: hi")))
+		})
+	})
+})
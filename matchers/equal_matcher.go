@@ -34,9 +34,14 @@ func (matcher *EqualMatcher) FailureMessage(actual interface{}) (message string)
 		return format.MessageWithDiff(actualString, "to equal", expectedString)
 	}
 
-	return format.Message(actual, "to equal", matcher.Expected)
+	return format.MessageWithStructuralDiff(actual, "to equal", matcher.Expected)
 }
 
 func (matcher *EqualMatcher) NegatedFailureMessage(actual interface{}) (message string) {
 	return format.Message(actual, "not to equal", matcher.Expected)
 }
+
+//Differences returns the paths at which actual differs from the expected value.  See format.Diff.
+func (matcher *EqualMatcher) Differences(actual interface{}) []format.Difference {
+	return format.Diff(actual, matcher.Expected)
+}
@@ -6,6 +6,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/format"
 	. "github.com/onsi/gomega/matchers"
 )
 
@@ -63,9 +64,32 @@ var _ = Describe("Equal", func() {
 			failureMessage := subject.FailureMessage(stringWithB)
 			Expect(failureMessage).To(BeEquivalentTo(expectedLongStringFailureMessage))
 		})
+
+		It("shows only the paths at which two composite values differ", func() {
+			subject := EqualMatcher{Expected: myCustomType{s: "foo", n: 3, f: 2.0, arr: []string{"a", "c"}}}
+
+			failureMessage := subject.FailureMessage(myCustomType{s: "bar", n: 3, f: 2.0, arr: []string{"a", "b"}})
+			Expect(failureMessage).To(Equal(expectedStructuralDiffFailureMessage))
+		})
+
+		It("lists the differences", func() {
+			subject := EqualMatcher{Expected: []int{1, 2}}
+
+			Expect(subject.Differences([]int{1, 3})).To(Equal([]format.Difference{{Path: "[1]", Actual: "3", Expected: "2"}}))
+		})
 	})
 })
 
+var expectedStructuralDiffFailureMessage = strings.TrimSpace(`
+Expected
+    <matchers_test.myCustomType>
+to equal
+    <matchers_test.myCustomType>
+but they differ at (actual != expected):
+    .s: "bar" != "foo"
+    .arr[1]: "b" != "c"
+`)
+
 var expectedShortStringFailureMessage = strings.TrimSpace(`
 Expected
     <string>: tim
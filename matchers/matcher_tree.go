@@ -0,0 +1,122 @@
+package matchers
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/types"
+)
+
+// And, Or and DescribedAs render their failures as an indented tree when they are nested, showing
+// which branches passed, which failed and which were never evaluated:
+//
+//    Expected
+//        <string>: hi
+//    to satisfy all of:
+//      ✓ to have length 2
+//      ✗ greeting: to satisfy any of:
+//          ✗ to equal
+//                <string>: hello
+//          ✗ to equal
+//                <string>: howdy
+//      - not evaluated: MatchRegexpMatcher
+
+type matchResult int
+
+const (
+	notEvaluated matchResult = iota
+	matched
+	didNotMatch
+)
+
+// treeMatcher is implemented by the matchers that render as a branch of the tree
+type treeMatcher interface {
+	types.GomegaMatcher
+	treeHeadline(actual interface{}) string
+	writeTreeChildren(buffer *strings.Builder, actual interface{}, indentation string)
+}
+
+func hasTreeMatcher(matchers []types.GomegaMatcher) bool {
+	for _, matcher := range matchers {
+		if _, ok := matcher.(treeMatcher); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func treeFailureMessage(matcher treeMatcher, actual interface{}) string {
+	buffer := &strings.Builder{}
+	buffer.WriteString(expectedPreamble(actual))
+	buffer.WriteString(matcher.treeHeadline(actual))
+	matcher.writeTreeChildren(buffer, actual, "  ")
+	return buffer.String()
+}
+
+func writeTreeNode(buffer *strings.Builder, matcher types.GomegaMatcher, result matchResult, actual interface{}, indentation string) {
+	buffer.WriteString("\n" + indentation)
+	switch result {
+	case notEvaluated:
+		buffer.WriteString("- not evaluated: " + matcherTypeName(matcher))
+		return
+	case matched:
+		buffer.WriteString("✓ ")
+	case didNotMatch:
+		buffer.WriteString("✗ ")
+	}
+
+	if tree, ok := matcher.(treeMatcher); ok {
+		buffer.WriteString(indentLines(tree.treeHeadline(actual), indentation+"  "))
+		tree.writeTreeChildren(buffer, actual, indentation+"    ")
+		return
+	}
+	description, ok := leafDescription(matcher, actual)
+	if !ok {
+		buffer.WriteString(matcherTypeName(matcher) + ":")
+		writeLeafMessage(buffer, description, indentation+"    ")
+		return
+	}
+	buffer.WriteString(indentLines(description, indentation+"  "))
+}
+
+// leafDescription describes what the matcher expects of actual, e.g. "to have length 2".  It is the matcher's
+// failure message without the leading "Expected <actual>".  If that could not be removed, ok is false and
+// description is the whole failure message.
+func leafDescription(matcher types.GomegaMatcher, actual interface{}) (description string, ok bool) {
+	defer func() {
+		// matchers are not obliged to produce a failure message for values they matched
+		if recover() != nil {
+			description, ok = matcherTypeName(matcher), true
+		}
+	}()
+	message := matcher.FailureMessage(actual)
+	preamble := expectedPreamble(actual)
+	if !strings.HasPrefix(message, preamble) {
+		return message, false
+	}
+	return strings.TrimPrefix(message, preamble), true
+}
+
+// writeLeafMessage writes a failure message that leafDescription could not reduce to a description as a block of its
+// own on the lines below the node
+func writeLeafMessage(buffer *strings.Builder, message string, indentation string) {
+	buffer.WriteString("\n" + indentation + indentLines(message, indentation))
+}
+
+func indentLines(s string, indentation string) string {
+	return strings.Replace(s, "\n", "\n"+indentation, -1)
+}
+
+func expectedPreamble(actual interface{}) string {
+	return fmt.Sprintf("Expected\n%s\n", format.Object(actual, 1))
+}
+
+func matcherTypeName(matcher types.GomegaMatcher) string {
+	t := reflect.TypeOf(matcher)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Name()
+}
@@ -2,6 +2,7 @@ package matchers
 
 import (
 	"github.com/onsi/gomega/internal/oraclematcher"
+	"github.com/onsi/gomega/internal/returnvaluesmatcher"
 	"github.com/onsi/gomega/types"
 )
 
@@ -28,3 +29,7 @@ func (m *NotMatcher) NegatedFailureMessage(actual interface{}) (message string)
 func (m *NotMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
 	return oraclematcher.MatchMayChangeInTheFuture(m.Matcher, actual) // just return m.Matcher's value
 }
+
+func (m *NotMatcher) MatchesReturnValues() bool {
+	return returnvaluesmatcher.MatchesReturnValues(m.Matcher)
+}
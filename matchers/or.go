@@ -2,9 +2,11 @@ package matchers
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/onsi/gomega/format"
 	"github.com/onsi/gomega/internal/oraclematcher"
+	"github.com/onsi/gomega/internal/returnvaluesmatcher"
 	"github.com/onsi/gomega/types"
 )
 
@@ -13,24 +15,32 @@ type OrMatcher struct {
 
 	// state
 	firstSuccessfulMatcher types.GomegaMatcher
+	results                []matchResult
 }
 
 func (m *OrMatcher) Match(actual interface{}) (success bool, err error) {
 	m.firstSuccessfulMatcher = nil
-	for _, matcher := range m.Matchers {
+	m.results = make([]matchResult, len(m.Matchers))
+	for i, matcher := range m.Matchers {
 		success, err := matcher.Match(actual)
 		if err != nil {
+			m.results[i] = didNotMatch
 			return false, err
 		}
 		if success {
 			m.firstSuccessfulMatcher = matcher
+			m.results[i] = matched
 			return true, nil
 		}
+		m.results[i] = didNotMatch
 	}
 	return false, nil
 }
 
 func (m *OrMatcher) FailureMessage(actual interface{}) (message string) {
+	if hasTreeMatcher(m.Matchers) {
+		return treeFailureMessage(m, actual)
+	}
 	// not the most beautiful list of matchers, but not bad either...
 	return format.Message(actual, fmt.Sprintf("To satisfy at least one of these matchers: %s", m.Matchers))
 }
@@ -39,6 +49,16 @@ func (m *OrMatcher) NegatedFailureMessage(actual interface{}) (message string) {
 	return m.firstSuccessfulMatcher.NegatedFailureMessage(actual)
 }
 
+func (m *OrMatcher) treeHeadline(actual interface{}) string {
+	return "to satisfy any of:"
+}
+
+func (m *OrMatcher) writeTreeChildren(buffer *strings.Builder, actual interface{}, indentation string) {
+	for i, matcher := range m.Matchers {
+		writeTreeNode(buffer, matcher, m.results[i], actual, indentation)
+	}
+}
+
 func (m *OrMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
 	/*
 		Example with 3 matchers: A, B, C
@@ -65,3 +85,7 @@ func (m *OrMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
 		return false // none of were going to change
 	}
 }
+
+func (m *OrMatcher) MatchesReturnValues() bool {
+	return returnvaluesmatcher.AllMatchReturnValues(m.Matchers)
+}
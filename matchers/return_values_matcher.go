@@ -0,0 +1,93 @@
+package matchers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/internal/oraclematcher"
+	"github.com/onsi/gomega/types"
+)
+
+type ReturnValuesMatcher struct {
+	Matchers []types.GomegaMatcher
+
+	// state
+	failed      bool
+	failedIndex int
+}
+
+func (m *ReturnValuesMatcher) Match(actual interface{}) (success bool, err error) {
+	m.failed, m.failedIndex = false, 0
+	values, ok := actual.([]interface{})
+	if !ok {
+		return false, fmt.Errorf("ReturnValues matcher expects the return values of a function.  Got:\n%s", format.Object(actual, 1))
+	}
+	if len(values) != len(m.Matchers) {
+		return false, fmt.Errorf("ReturnValues matcher was given %d matchers for %d return values:\n%s", len(m.Matchers), len(values), format.Object(values, 1))
+	}
+
+	for i, matcher := range m.Matchers {
+		success, err := matcher.Match(values[i])
+		if err != nil {
+			return false, fmt.Errorf("Return value at index %d: %s", i, err.Error())
+		}
+		if !success {
+			m.failed, m.failedIndex = true, i
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func (m *ReturnValuesMatcher) FailureMessage(actual interface{}) (message string) {
+	values, ok := actual.([]interface{})
+	if !ok || !m.failed || m.failedIndex >= len(values) || m.failedIndex >= len(m.Matchers) {
+		return format.Message(actual, "to have return values matching:\n"+m.describeMatchers(actual, false))
+	}
+	return fmt.Sprintf("Return value at index %d did not match:\n%s", m.failedIndex, m.Matchers[m.failedIndex].FailureMessage(values[m.failedIndex]))
+}
+
+func (m *ReturnValuesMatcher) NegatedFailureMessage(actual interface{}) (message string) {
+	return format.Message(actual, "not to have return values matching:\n"+m.describeMatchers(actual, true))
+}
+
+// describeMatchers lists the matcher for each return value position, described by its own failure message for the
+// value at that position when there is one
+func (m *ReturnValuesMatcher) describeMatchers(actual interface{}, negated bool) string {
+	values, _ := actual.([]interface{})
+	descriptions := make([]string, len(m.Matchers))
+	for i, matcher := range m.Matchers {
+		var description string
+		switch {
+		case i >= len(values):
+			description = format.Object(matcher, 0)
+		case negated:
+			description = matcher.NegatedFailureMessage(values[i])
+		default:
+			description = matcher.FailureMessage(values[i])
+		}
+		descriptions[i] = format.IndentString(fmt.Sprintf("Return value at index %d:", i), 1) + "\n" + format.IndentString(description, 2)
+	}
+	return strings.Join(descriptions, "\n")
+}
+
+func (m *ReturnValuesMatcher) MatchesReturnValues() bool {
+	return true
+}
+
+func (m *ReturnValuesMatcher) MatchMayChangeInTheFuture(actual interface{}) bool {
+	values, ok := actual.([]interface{})
+	if !ok || len(values) != len(m.Matchers) {
+		return false
+	}
+	if m.failed {
+		return oraclematcher.MatchMayChangeInTheFuture(m.Matchers[m.failedIndex], values[m.failedIndex])
+	}
+	for i, matcher := range m.Matchers {
+		if oraclematcher.MatchMayChangeInTheFuture(matcher, values[i]) {
+			return true
+		}
+	}
+	return false
+}
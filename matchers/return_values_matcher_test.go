@@ -0,0 +1,74 @@
+package matchers_test
+
+import (
+	"errors"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/matchers"
+	"github.com/onsi/gomega/types"
+)
+
+var _ = Describe("ReturnValuesMatcher", func() {
+	returns := func(values ...interface{}) []interface{} {
+		return values
+	}
+
+	It("succeeds when every return value matches the matcher in its position", func() {
+		Expect(returns(3, "xyz", nil)).Should(ReturnValues(Equal(3), HavePrefix("x"), Succeed()))
+		Expect(returns(3, "xyz", nil)).ShouldNot(ReturnValues(Equal(3), HavePrefix("y"), Succeed()))
+		Expect(returns()).Should(ReturnValues())
+	})
+
+	It("names the first return value that did not match", func() {
+		values := returns(3, "xyz", errors.New("boom"))
+		matcher := &ReturnValuesMatcher{Matchers: []types.GomegaMatcher{Equal(3), HavePrefix("y"), Succeed()}}
+		Expect(matcher.Match(values)).Should(BeFalse())
+		Expect(matcher.FailureMessage(values)).Should(Equal("Return value at index 1 did not match:\nExpected\n    <string>: xyz\nto have prefix\n    <string>: y"))
+	})
+
+	It("describes the negated failure", func() {
+		values := returns(3)
+		matcher := ReturnValues(Equal(3))
+		Expect(matcher.NegatedFailureMessage(values)).Should(Equal("Expected\n    <[]interface {} | len:1, cap:1>: [<int>3]\nnot to have return values matching:\n    Return value at index 0:\n        Expected\n            <int>: 3\n        not to equal\n            <int>: 3"))
+	})
+
+	It("errors when the values are not return values", func() {
+		success, err := ReturnValues(Equal(3)).Match(3)
+		Expect(success).Should(BeFalse())
+		Expect(err).Should(MatchError(ContainSubstring("ReturnValues matcher expects the return values of a function")))
+	})
+
+	It("errors when the number of matchers and return values differ", func() {
+		success, err := ReturnValues(Equal(3)).Match(returns(3, nil))
+		Expect(success).Should(BeFalse())
+		Expect(err).Should(MatchError(ContainSubstring("ReturnValues matcher was given 1 matchers for 2 return values")))
+	})
+
+	It("names the return value a matcher errored on", func() {
+		success, err := ReturnValues(Equal(3), BeNumerically(">", 1)).Match(returns(3, "x"))
+		Expect(success).Should(BeFalse())
+		Expect(err).Should(MatchError(HavePrefix("Return value at index 1: ")))
+	})
+
+	It("describes the failure without naming an index when no return value failed", func() {
+		matcher := ReturnValues(Equal(3))
+		Expect(matcher.FailureMessage(returns(4))).Should(Equal("Expected\n    <[]interface {} | len:1, cap:1>: [<int>4]\nto have return values matching:\n    Return value at index 0:\n        Expected\n            <int>: 4\n        to equal\n            <int>: 3"))
+
+		matcher = ReturnValues(Equal(3), BeNil())
+		_, err := matcher.Match(returns(3))
+		Expect(err).Should(HaveOccurred())
+		Expect(matcher.FailureMessage(returns(3))).Should(MatchRegexp(`^Expected\n    <\[\]interface {} \| len:1, cap:1>: \[<int>3\]\nto have return values matching:\n    Return value at index 0:\n        Expected\n            <int>: 3\n        to equal\n            <int>: 3\n    Return value at index 1:\n        <\*matchers.BeNilMatcher \| 0x[0-9a-f]+>: {}$`))
+	})
+
+	It("says it matches return values, as do the matchers wrapping it", func() {
+		Expect(ReturnValues(Equal(3)).(*ReturnValuesMatcher).MatchesReturnValues()).Should(BeTrue())
+		Expect(Not(ReturnValues(Equal(3))).(*NotMatcher).MatchesReturnValues()).Should(BeTrue())
+		Expect(DescribedAs("fetch", ReturnValues(Equal(3))).(*DescribedAsMatcher).MatchesReturnValues()).Should(BeTrue())
+		Expect(And(ReturnValues(Equal(3)), Not(ReturnValues(Equal(4)))).(*AndMatcher).MatchesReturnValues()).Should(BeTrue())
+		Expect(Or(ReturnValues(Equal(3)), ReturnValues(Equal(4))).(*OrMatcher).MatchesReturnValues()).Should(BeTrue())
+
+		Expect(Not(Equal(3)).(*NotMatcher).MatchesReturnValues()).Should(BeFalse())
+		Expect(And(ReturnValues(Equal(3)), HaveLen(1)).(*AndMatcher).MatchesReturnValues()).Should(BeFalse())
+	})
+})
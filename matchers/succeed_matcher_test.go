@@ -63,7 +63,7 @@ var _ = Describe("Succeed", func() {
 	It("builds failure message", func() {
 		actual := Succeed().FailureMessage(errors.New("oops"))
 		actual = regexp.MustCompile(" 0x.*>").ReplaceAllString(actual, " 0x00000000>")
-		Expect(actual).To(Equal("Expected success, but got an error:\n    <*errors.errorString | 0x00000000>: {s: \"oops\"}\n    oops"))
+		Expect(actual).To(Equal("Expected success, but got an error:\n    <*errors.errorString | 0x00000000>: oops\n    oops"))
 	})
 
 	It("builds negated failure message", func() {
@@ -0,0 +1,102 @@
+package gomega
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/onsi/gomega/internal/testingtsupport"
+	"github.com/onsi/gomega/types"
+)
+
+// SoftGomega is a Gomega instance that records failed assertions instead of stopping the test at the first one.
+// Verify fails the test with every recorded failure at once:
+//
+//    func TestUserResponse(t *testing.T) {
+//        g := NewSoftGomega(t)
+//
+//        user := client.FetchUser(1138)
+//        g.Expect(user.Name).To(Equal("Dr. Manhattan"))
+//        g.Expect(user.Email).To(HaveSuffix("@watchmen.com"))
+//        g.Eventually(user.Status).Should(Equal("active"))
+//    }
+//
+// If t has a Cleanup method, as *testing.T does, Verify is called automatically when the test finishes, so recorded
+// failures are never lost.  Call Verify yourself to report them earlier.
+//
+// Failures from Eventually and Consistently are recorded too.  A SoftGomega is safe to use from multiple goroutines.
+type SoftGomega struct {
+	*WithT
+	failWrapper *types.GomegaFailWrapper
+
+	lock     sync.Mutex
+	failures []softFailure
+}
+
+type softFailure struct {
+	location string
+	message  string
+}
+
+// NewSoftGomega returns a SoftGomega that reports its failures to t when Verify is called.
+// GomegaOptions configure its Eventually and Consistently defaults, as with NewGomega.
+func NewSoftGomega(t types.GomegaTestingT, options ...GomegaOption) *SoftGomega {
+	// the fail wrapper registers its own Cleanup first, so that Verify's Cleanup runs before the test counts as completed
+	g := &SoftGomega{failWrapper: testingtsupport.BuildTestingTGomegaFailWrapper(t)}
+	g.WithT = NewGomega(g.recordFailure, options...)
+	if tWithCleanup, ok := t.(interface{ Cleanup(func()) }); ok {
+		tWithCleanup.Cleanup(g.Verify)
+	}
+	return g
+}
+
+func (g *SoftGomega) recordFailure(message string, callerSkip ...int) {
+	skip := 0
+	if len(callerSkip) > 0 {
+		skip = callerSkip[0]
+	}
+	location := "unknown location"
+	if _, file, line, ok := runtime.Caller(skip + 1); ok {
+		location = fmt.Sprintf("%s:%d", file, line)
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.failures = append(g.failures, softFailure{location: location, message: message})
+}
+
+// Failures returns the failures recorded so far, each prefixed with the location of the failed assertion.
+func (g *SoftGomega) Failures() []string {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	failures := []string{}
+	for _, failure := range g.failures {
+		failures = append(failures, failure.location+"\n"+failure.message)
+	}
+	return failures
+}
+
+// Verify fails the test with every failure recorded so far, and clears them.  It does nothing if no assertion failed
+// since the last call, so each failure is reported once however often Verify is called.
+func (g *SoftGomega) Verify() {
+	g.lock.Lock()
+	failures := g.failures
+	g.failures = nil
+	g.lock.Unlock()
+
+	if len(failures) == 0 {
+		return
+	}
+
+	report := []string{}
+	for i, failure := range failures {
+		report = append(report, fmt.Sprintf("%d) %s\n%s", i+1, failure.location, failure.message))
+	}
+	summary := fmt.Sprintf("%d assertions failed:", len(failures))
+	if len(failures) == 1 {
+		summary = "1 assertion failed:"
+	}
+	g.failWrapper.TWithHelper.Helper()
+	g.failWrapper.Fail(summary+"\n\n"+strings.Join(report, "\n\n"), 1)
+}
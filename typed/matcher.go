@@ -0,0 +1,56 @@
+package typed
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/onsi/gomega/format"
+	"github.com/onsi/gomega/internal/oraclematcher"
+	"github.com/onsi/gomega/types"
+)
+
+//Matcher matches values of type T.
+//
+//Matcher implements types.GomegaMatcher.  When it is used with the untyped DSL it fails with an error if the actual
+//value is not a T.
+type Matcher[T any] struct {
+	matcher types.GomegaMatcher
+}
+
+//Adapt turns an untyped matcher into a Matcher[T].  It is up to you to check that the matcher accepts values of type T.
+func Adapt[T any](matcher types.GomegaMatcher) Matcher[T] {
+	return Matcher[T]{matcher: matcher}
+}
+
+func (m Matcher[T]) Match(actual interface{}) (success bool, err error) {
+	if actual != nil {
+		if _, ok := actual.(T); !ok {
+			return false, fmt.Errorf("Expected a value of type %s.  Got:\n%s", typeName[T](), format.Object(actual, 1))
+		}
+	}
+	return m.matcher.Match(actual)
+}
+
+func (m Matcher[T]) FailureMessage(actual interface{}) (message string) {
+	return m.matcher.FailureMessage(actual)
+}
+
+func (m Matcher[T]) NegatedFailureMessage(actual interface{}) (message string) {
+	return m.matcher.NegatedFailureMessage(actual)
+}
+
+func (m Matcher[T]) MatchMayChangeInTheFuture(actual interface{}) bool {
+	return oraclematcher.MatchMayChangeInTheFuture(m.matcher, actual)
+}
+
+func typeName[T any]() string {
+	return reflect.TypeOf((*T)(nil)).Elem().String()
+}
+
+func untyped[T any](ms []Matcher[T]) []types.GomegaMatcher {
+	matchers := make([]types.GomegaMatcher, len(ms))
+	for i, m := range ms {
+		matchers[i] = m
+	}
+	return matchers
+}
@@ -0,0 +1,193 @@
+package typed
+
+import (
+	"time"
+
+	"github.com/onsi/gomega"
+)
+
+//Number is satisfied by Go's integer and floating point types, and any type derived from them.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+//Equal uses reflect.DeepEqual to compare actual with expected.  See gomega.Equal.
+func Equal[T any](expected T) Matcher[T] {
+	return Adapt[T](gomega.Equal(expected))
+}
+
+//BeIdenticalTo uses the == operator to compare actual with expected.  See gomega.BeIdenticalTo.
+func BeIdenticalTo[T comparable](expected T) Matcher[T] {
+	return Adapt[T](gomega.BeIdenticalTo(expected))
+}
+
+//BeNil succeeds if actual is nil.  T is typically a pointer, slice, map, channel, func or interface type.
+func BeNil[T any]() Matcher[T] {
+	return Adapt[T](gomega.BeNil())
+}
+
+//BeZero succeeds if actual is the zero value of T.
+func BeZero[T any]() Matcher[T] {
+	return Adapt[T](gomega.BeZero())
+}
+
+//BeTrue succeeds if actual is true
+func BeTrue() Matcher[bool] {
+	return Adapt[bool](gomega.BeTrue())
+}
+
+//BeFalse succeeds if actual is false
+func BeFalse() Matcher[bool] {
+	return Adapt[bool](gomega.BeFalse())
+}
+
+//HaveOccurred succeeds if actual is a non-nil error
+//    typed.Expect(err).ShouldNot(typed.HaveOccurred())
+func HaveOccurred() Matcher[error] {
+	return Adapt[error](gomega.HaveOccurred())
+}
+
+//Succeed passes if actual is a nil error
+//    typed.Expect(os.Remove(path)).To(typed.Succeed())
+func Succeed() Matcher[error] {
+	return Adapt[error](gomega.Succeed())
+}
+
+//MatchError succeeds if actual is a non-nil error that matches expected: an error, a string or a matcher.
+//See gomega.MatchError.
+func MatchError(expected interface{}) Matcher[error] {
+	return Adapt[error](gomega.MatchError(expected))
+}
+
+//MatchRegexp succeeds if actual matches the regular expression.  See gomega.MatchRegexp.
+func MatchRegexp(regexp string, args ...interface{}) Matcher[string] {
+	return Adapt[string](gomega.MatchRegexp(regexp, args...))
+}
+
+//ContainSubstring succeeds if actual contains substr.  See gomega.ContainSubstring.
+func ContainSubstring(substr string, args ...interface{}) Matcher[string] {
+	return Adapt[string](gomega.ContainSubstring(substr, args...))
+}
+
+//HavePrefix succeeds if actual starts with prefix.  See gomega.HavePrefix.
+func HavePrefix(prefix string, args ...interface{}) Matcher[string] {
+	return Adapt[string](gomega.HavePrefix(prefix, args...))
+}
+
+//HaveSuffix succeeds if actual ends with suffix.  See gomega.HaveSuffix.
+func HaveSuffix(suffix string, args ...interface{}) Matcher[string] {
+	return Adapt[string](gomega.HaveSuffix(suffix, args...))
+}
+
+//BeEmpty succeeds if actual is empty.  T must be a string, array, slice, map or channel type.
+func BeEmpty[T any]() Matcher[T] {
+	return Adapt[T](gomega.BeEmpty())
+}
+
+//HaveLen succeeds if actual has the passed-in length.  T must be a string, array, slice, map or channel type.
+func HaveLen[T any](count int) Matcher[T] {
+	return Adapt[T](gomega.HaveLen(count))
+}
+
+//ContainElement succeeds if the slice contains element.
+//    typed.Expect([]string{"Foo", "FooBar"}).To(typed.ContainElement("Foo"))
+func ContainElement[E any](element E) Matcher[[]E] {
+	return Adapt[[]E](gomega.ContainElement(element))
+}
+
+//ContainElementMatching succeeds if the slice contains an element satisfying matcher.
+//    typed.Expect([]string{"Foo", "FooBar"}).To(typed.ContainElementMatching(typed.HavePrefix("FooB")))
+func ContainElementMatching[E any](matcher Matcher[E]) Matcher[[]E] {
+	return Adapt[[]E](gomega.ContainElement(matcher))
+}
+
+//ContainElements succeeds if the slice contains all of the elements, in any order.  See gomega.ContainElements.
+func ContainElements[E any](elements ...E) Matcher[[]E] {
+	return Adapt[[]E](gomega.ContainElements(toInterfaces(elements)...))
+}
+
+//ConsistOf succeeds if the slice contains precisely the elements, in any order.  See gomega.ConsistOf.
+func ConsistOf[E any](elements ...E) Matcher[[]E] {
+	return Adapt[[]E](gomega.ConsistOf(toInterfaces(elements)...))
+}
+
+//BeElementOf succeeds if actual is equal to one of the elements.
+//    typed.Expect(2).To(typed.BeElementOf(1, 2))
+func BeElementOf[T any](elements ...T) Matcher[T] {
+	return Adapt[T](gomega.BeElementOf(toInterfaces(elements)...))
+}
+
+//HaveKey succeeds if the map has the key.  Map types cannot be inferred from a key, so V must be given:
+//    typed.Expect(map[string]int{"Foo": 1}).To(typed.HaveKey[string, int]("Foo"))
+func HaveKey[K comparable, V any](key K) Matcher[map[K]V] {
+	return Adapt[map[K]V](gomega.HaveKey(key))
+}
+
+//HaveKeyWithValue succeeds if the map has the key, and the value at that key is value.
+//    typed.Expect(map[string]int{"Foo": 1}).To(typed.HaveKeyWithValue("Foo", 1))
+func HaveKeyWithValue[K comparable, V any](key K, value V) Matcher[map[K]V] {
+	return Adapt[map[K]V](gomega.HaveKeyWithValue(key, value))
+}
+
+//BeNumerically performs numerical assertions in a type-agnostic way.  See gomega.BeNumerically.
+//    typed.Expect(1.0).To(typed.BeNumerically("~", 0.999, 0.01))
+func BeNumerically[T Number](comparator string, compareTo ...T) Matcher[T] {
+	return Adapt[T](gomega.BeNumerically(comparator, toInterfaces(compareTo)...))
+}
+
+//BeTemporally compares time.Time's like BeNumerically.  See gomega.BeTemporally.
+func BeTemporally(comparator string, compareTo time.Time, threshold ...time.Duration) Matcher[time.Time] {
+	return Adapt[time.Time](gomega.BeTemporally(comparator, compareTo, threshold...))
+}
+
+//Panic succeeds if actual panics when called.
+func Panic() Matcher[func()] {
+	return Adapt[func()](gomega.Panic())
+}
+
+//And succeeds only if all of the given matchers succeed.  See gomega.And.
+func And[T any](ms ...Matcher[T]) Matcher[T] {
+	return Adapt[T](gomega.And(untyped(ms)...))
+}
+
+//SatisfyAll is an alias for And().
+func SatisfyAll[T any](ms ...Matcher[T]) Matcher[T] {
+	return And(ms...)
+}
+
+//Or succeeds if any of the given matchers succeed.  See gomega.Or.
+func Or[T any](ms ...Matcher[T]) Matcher[T] {
+	return Adapt[T](gomega.Or(untyped(ms)...))
+}
+
+//SatisfyAny is an alias for Or().
+func SatisfyAny[T any](ms ...Matcher[T]) Matcher[T] {
+	return Or(ms...)
+}
+
+//Not negates the given matcher; it succeeds if the given matcher fails.
+func Not[T any](matcher Matcher[T]) Matcher[T] {
+	return Adapt[T](gomega.Not(matcher))
+}
+
+//WithTransform applies transform to the actual value and matches the result against matcher.
+//    typed.Expect(farm).To(typed.WithTransform((*Farm).Name, typed.Equal("Old McDonald's")))
+func WithTransform[T any, U any](transform func(T) U, matcher Matcher[U]) Matcher[T] {
+	return Adapt[T](gomega.WithTransform(transform, matcher))
+}
+
+//Satisfy matches the actual value against predicate.
+//    typed.Expect(2).To(typed.Satisfy(func(i int) bool { return i%2 == 0 }))
+func Satisfy[T any](predicate func(T) bool) Matcher[T] {
+	return Adapt[T](gomega.Satisfy(predicate))
+}
+
+func toInterfaces[T any](values []T) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = value
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package typed_test
+
+import (
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/typed"
+)
+
+type myInt int
+
+var _ = Describe("Typed matchers", func() {
+	matches := func(matcher interface {
+		Match(actual interface{}) (bool, error)
+	}, actual interface{}, expected bool) {
+		success, err := matcher.Match(actual)
+		Expect(err).ShouldNot(HaveOccurred())
+		Expect(success).Should(Equal(expected))
+	}
+
+	now := time.Now()
+	err := errors.New("boom")
+
+	DescribeTable("wrap the untyped matchers",
+		matches,
+		Entry("Equal", typed.Equal(3), 3, true),
+		Entry("Equal", typed.Equal(3), 4, false),
+		Entry("BeIdenticalTo", typed.BeIdenticalTo("a"), "a", true),
+		Entry("BeNil", typed.BeNil[*int](), (*int)(nil), true),
+		Entry("BeZero", typed.BeZero[int](), 0, true),
+		Entry("BeTrue", typed.BeTrue(), true, true),
+		Entry("BeFalse", typed.BeFalse(), true, false),
+		Entry("HaveOccurred", typed.HaveOccurred(), err, true),
+		Entry("Succeed", typed.Succeed(), nil, true),
+		Entry("MatchError", typed.MatchError("boom"), err, true),
+		Entry("MatchRegexp", typed.MatchRegexp(`^f.o$`), "foo", true),
+		Entry("ContainSubstring", typed.ContainSubstring("o"), "foo", true),
+		Entry("HavePrefix", typed.HavePrefix("b"), "foo", false),
+		Entry("HaveSuffix", typed.HaveSuffix("oo"), "foo", true),
+		Entry("BeEmpty", typed.BeEmpty[[]int](), []int{}, true),
+		Entry("HaveLen", typed.HaveLen[string](3), "foo", true),
+		Entry("ContainElement", typed.ContainElement(2), []int{1, 2}, true),
+		Entry("ContainElementMatching", typed.ContainElementMatching(typed.BeNumerically(">", 1)), []int{1, 2}, true),
+		Entry("ContainElements", typed.ContainElements(2, 1), []int{1, 2, 3}, true),
+		Entry("ConsistOf", typed.ConsistOf(2, 1), []int{1, 2, 3}, false),
+		Entry("BeElementOf", typed.BeElementOf("a", "b"), "b", true),
+		Entry("HaveKey", typed.HaveKey[string, int]("a"), map[string]int{"a": 1}, true),
+		Entry("HaveKeyWithValue", typed.HaveKeyWithValue("a", 2), map[string]int{"a": 1}, false),
+		Entry("BeNumerically", typed.BeNumerically("~", 1.0, 0.01), 1.001, true),
+		Entry("BeNumerically with a derived type", typed.BeNumerically[myInt](">", 2), myInt(3), true),
+		Entry("BeTemporally", typed.BeTemporally("<", now), now.Add(-time.Second), true),
+		Entry("Panic", typed.Panic(), func() { panic("boom") }, true),
+		Entry("And", typed.And(typed.HavePrefix("f"), typed.HaveLen[string](3)), "foo", true),
+		Entry("SatisfyAll", typed.SatisfyAll(typed.HavePrefix("f"), typed.HaveLen[string](2)), "foo", false),
+		Entry("Or", typed.Or(typed.HavePrefix("b"), typed.HaveLen[string](3)), "foo", true),
+		Entry("SatisfyAny", typed.SatisfyAny(typed.HavePrefix("b"), typed.HaveLen[string](2)), "foo", false),
+		Entry("Not", typed.Not(typed.Equal(3)), 4, true),
+		Entry("WithTransform", typed.WithTransform(func(s string) int { return len(s) }, typed.Equal(3)), "foo", true),
+		Entry("Satisfy", typed.Satisfy(func(i int) bool { return i%2 == 0 }), 3, false),
+	)
+})
@@ -0,0 +1,98 @@
+/*
+Package typed provides a generics-based flavour of Gomega's DSL in which the compiler checks that a matcher can be
+applied to the actual value:
+
+    typed.Expect(len(cows)).To(typed.BeNumerically(">", 2))
+    typed.Expect(farm.Name()).To(typed.HavePrefix("Old"))
+    typed.Expect(farm.Name()).To(typed.Equal(3)) // does not compile
+
+A typed.Matcher[T] is also a types.GomegaMatcher, so it can be handed to the untyped DSL - to Eventually, say - and
+any untyped matcher can be adopted with Adapt:
+
+    typed.Expect(farm.Animals()).To(typed.Adapt[[]string](gstruct.MatchAllElements(id, elements)))
+
+Like Gomega's Expect, typed.Expect reports failures to the global fail handler.  Use NewWithT and ExpectWith with
+a *testing.T.
+*/
+package typed
+
+import (
+	"github.com/onsi/gomega"
+	"github.com/onsi/gomega/internal/testingtsupport"
+	"github.com/onsi/gomega/types"
+)
+
+//Assertion is returned by Expect and ExpectWith.  It only accepts matchers for values of type T.
+type Assertion[T any] struct {
+	assertion types.Assertion
+	helper    types.TWithHelper
+}
+
+func (a Assertion[T]) Should(matcher Matcher[T], optionalDescription ...interface{}) bool {
+	a.helper.Helper()
+	return a.assertion.Should(matcher, optionalDescription...)
+}
+
+func (a Assertion[T]) ShouldNot(matcher Matcher[T], optionalDescription ...interface{}) bool {
+	a.helper.Helper()
+	return a.assertion.ShouldNot(matcher, optionalDescription...)
+}
+
+func (a Assertion[T]) To(matcher Matcher[T], optionalDescription ...interface{}) bool {
+	a.helper.Helper()
+	return a.assertion.To(matcher, optionalDescription...)
+}
+
+func (a Assertion[T]) ToNot(matcher Matcher[T], optionalDescription ...interface{}) bool {
+	a.helper.Helper()
+	return a.assertion.ToNot(matcher, optionalDescription...)
+}
+
+func (a Assertion[T]) NotTo(matcher Matcher[T], optionalDescription ...interface{}) bool {
+	a.helper.Helper()
+	return a.assertion.NotTo(matcher, optionalDescription...)
+}
+
+//Expect is the typed counterpart of gomega.Expect.  As with gomega.Expect, any extra values must be nil or zero.
+func Expect[T any](actual T, extra ...interface{}) Assertion[T] {
+	return ExpectWithOffset(0, actual, extra...)
+}
+
+//ExpectWithOffset is the typed counterpart of gomega.ExpectWithOffset.
+func ExpectWithOffset[T any](offset int, actual T, extra ...interface{}) Assertion[T] {
+	return Assertion[T]{
+		assertion: gomega.ExpectWithOffset(offset+1, actual, extra...),
+		helper:    testingtsupport.EmptyTWithHelper{},
+	}
+}
+
+//WithT is a gomega.WithT for use with ExpectWith.
+//
+//    func TestFarmHasCow(t *testing.T) {
+//        g := typed.NewWithT(t)
+//        typed.ExpectWith(g, farm.Cows()).To(typed.ContainElement("Daisy"))
+//    }
+type WithT struct {
+	*gomega.WithT
+	helper types.TWithHelper
+}
+
+//NewWithT is the typed counterpart of gomega.NewWithT.
+func NewWithT(t types.GomegaTestingT) *WithT {
+	helper, ok := t.(types.TWithHelper)
+	if !ok {
+		helper = testingtsupport.EmptyTWithHelper{}
+	}
+	return &WithT{
+		WithT:  gomega.NewWithT(t),
+		helper: helper,
+	}
+}
+
+//ExpectWith is Expect for a WithT.
+func ExpectWith[T any](g *WithT, actual T, extra ...interface{}) Assertion[T] {
+	return Assertion[T]{
+		assertion: g.ExpectWithOffset(1, actual, extra...),
+		helper:    g.helper,
+	}
+}
@@ -0,0 +1,13 @@
+package typed_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"testing"
+)
+
+func TestTyped(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Typed Suite")
+}
@@ -0,0 +1,127 @@
+package typed_test
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/typed"
+)
+
+var _ = Describe("Typed assertions", func() {
+	It("passes the actual value to the matcher", func() {
+		Expect(typed.Expect("foo").To(typed.Equal("foo"))).Should(BeTrue())
+		Expect(typed.Expect("foo").Should(typed.HavePrefix("f"))).Should(BeTrue())
+		Expect(typed.Expect("foo").ShouldNot(typed.Equal("bar"))).Should(BeTrue())
+		Expect(typed.Expect("foo").ToNot(typed.Equal("bar"))).Should(BeTrue())
+		Expect(typed.Expect("foo").NotTo(typed.Equal("bar"))).Should(BeTrue())
+	})
+
+	It("reports failures to the global fail handler", func() {
+		failures := InterceptGomegaFailures(func() {
+			typed.Expect(3).To(typed.Equal(4), "three is %s", "not four")
+			typed.Expect(3).NotTo(typed.Equal(3))
+		})
+		Expect(failures).Should(HaveLen(2))
+		Expect(failures[0]).Should(HavePrefix("three is not four\nExpected\n    <int>: 3\nto equal\n    <int>: 4"))
+		Expect(failures[1]).Should(HavePrefix("Expected\n    <int>: 3\nnot to equal\n    <int>: 3"))
+	})
+
+	It("reports failures at the line of the assertion", func() {
+		_, _, line, _ := runtime.Caller(0)
+		location := failureLocation(func() { typed.Expect(3).To(typed.Equal(4)) })
+		Expect(location).Should(Equal(fmt.Sprintf("typed_test.go:%d", line+1)))
+
+		expectFour := func(actual int) {
+			typed.ExpectWithOffset(1, actual).To(typed.Equal(4))
+		}
+		_, _, line, _ = runtime.Caller(0)
+		location = failureLocation(func() { expectFour(3) })
+		Expect(location).Should(Equal(fmt.Sprintf("typed_test.go:%d", line+1)))
+	})
+
+	It("requires extra values to be zero", func() {
+		failures := InterceptGomegaFailures(func() {
+			typed.Expect(3, errors.New("boom")).To(typed.Equal(3))
+		})
+		Expect(failures).Should(ConsistOf(ContainSubstring("Unexpected non-nil/non-zero extra argument at index 1")))
+	})
+
+	Describe("Matchers with the untyped DSL", func() {
+		It("can be used as a types.GomegaMatcher", func() {
+			Expect(3).Should(typed.BeNumerically(">", 2))
+			Eventually(func() string { return "foo" }).Should(typed.Equal("foo"))
+		})
+
+		It("errors when the actual value has the wrong type", func() {
+			success, err := typed.Equal(3).Match("3")
+			Expect(success).Should(BeFalse())
+			Expect(err).Should(MatchError("Expected a value of type int.  Got:\n    <string>: 3"))
+		})
+
+		It("does not type check nil", func() {
+			Expect(typed.Succeed().Match(nil)).Should(BeTrue())
+		})
+
+		It("adapts untyped matchers", func() {
+			typed.Expect([]int{1, 2}).To(typed.Adapt[[]int](ConsistOf(2, 1)))
+			typed.Expect(3).To(typed.Not(typed.Adapt[int](BeNil())))
+		})
+
+		It("forwards MatchMayChangeInTheFuture", func() {
+			c := make(chan int)
+			close(c)
+			start := time.Now()
+			failures := InterceptGomegaFailures(func() {
+				Eventually(c, time.Second).Should(typed.Adapt[chan int](Receive()))
+			})
+			Expect(failures).Should(HaveLen(1))
+			Expect(time.Since(start)).Should(BeNumerically("<", time.Second))
+		})
+	})
+})
+
+//failureLocation runs f with a global fail handler that records the file:line the failure is reported at.
+func failureLocation(f func()) string {
+	var location string
+	RegisterFailHandler(func(message string, callerSkip ...int) {
+		_, file, line, _ := runtime.Caller(callerSkip[0] + 1)
+		location = fmt.Sprintf("%s:%d", filepath.Base(file), line)
+	})
+	defer RegisterFailHandler(Fail)
+	f()
+	return location
+}
+
+type fakeT struct {
+	failures []string
+	helpers  int
+}
+
+func (t *fakeT) Fatalf(format string, args ...interface{}) {
+	t.failures = append(t.failures, format)
+}
+
+func (t *fakeT) Helper() {
+	t.helpers++
+}
+
+func TestExpectWith(t *testing.T) {
+	g := NewWithT(t)
+
+	f := &fakeT{}
+	tg := typed.NewWithT(f)
+	g.Expect(typed.ExpectWith(tg, []string{"a", "b"}).To(typed.ContainElement("b"))).To(BeTrue())
+	g.Expect(f.failures).To(BeEmpty())
+	g.Expect(f.helpers).To(BeNumerically(">", 0))
+
+	g.Expect(typed.ExpectWith(tg, 3).To(typed.Equal(4))).To(BeFalse())
+	g.Expect(f.failures).To(HaveLen(1))
+
+	tg.Expect("untyped").To(Equal("untyped"))
+}
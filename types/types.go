@@ -1,14 +1,78 @@
 package types
 
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
 type TWithHelper interface {
 	Helper()
 }
 
 type GomegaFailHandler func(message string, callerSkip ...int)
 
+//GomegaFailureHandler is the structured counterpart of GomegaFailHandler.  It receives the failure's
+//raw values rather than a flattened message.  callerSkip has the same meaning as for GomegaFailHandler.
+type GomegaFailureHandler func(failure Failure, callerSkip ...int)
+
+//GomegaFailWrapper carries the handlers failures are reported to.  When FailureHandler is set it receives
+//assertion failures in place of Fail.
+//
+//Reporter, when set, is told the result of every assertion.
 type GomegaFailWrapper struct {
-	Fail        GomegaFailHandler
-	TWithHelper TWithHelper
+	Fail           GomegaFailHandler
+	FailureHandler GomegaFailureHandler
+	Reporter       AssertionReporter
+	TWithHelper    TWithHelper
+}
+
+//Failure describes a failed assertion.
+//
+//Message is the complete message, exactly as a GomegaFailHandler would receive it.  Description is the optional
+//description passed to Should, To, etc.  Actual is the value handed to the matcher: for Eventually and Consistently
+//that is the last polled value.  Expected is the matcher's exported Expected field, if it has one.
+//
+//Matcher is nil for failures that are not the result of a match, such as non-nil extra values passed to Expect.
+type Failure struct {
+	Message     string
+	Description string
+	Actual      interface{}
+	Expected    interface{}
+	Matcher     GomegaMatcher
+	Negated     bool
+	Location    FailureLocation
+}
+
+//FailureLocation is the line in the test at which an assertion was made.
+type FailureLocation struct {
+	FileName   string
+	LineNumber int
+}
+
+func (l FailureLocation) String() string {
+	return fmt.Sprintf("%s:%d", l.FileName, l.LineNumber)
+}
+
+//AssertionReporter is told the result of every assertion made with the GomegaFailWrapper it is attached to,
+//passing or failing.  It is told before the failure is handed to the fail handler.
+type AssertionReporter interface {
+	ReportAssertion(result AssertionResult)
+}
+
+//AssertionResult is the outcome of a single assertion.
+//
+//Assertion is "Expect", "Eventually" or "Consistently".  Message and Description are only set for failures, and
+//Duration, the time spent polling, only for Eventually and Consistently.
+type AssertionResult struct {
+	Assertion   string
+	Passed      bool
+	Message     string
+	Description string
+	Matcher     GomegaMatcher
+	Negated     bool
+	Location    FailureLocation
+	Duration    time.Duration
 }
 
 //A simple *testing.T interface wrapper
@@ -24,3 +88,112 @@ type GomegaMatcher interface {
 	FailureMessage(actual interface{}) (message string)
 	NegatedFailureMessage(actual interface{}) (message string)
 }
+
+//AsyncAssertion is returned by Eventually and Consistently and polls the actual value passed into Eventually against
+//the matcher passed to the Should and ShouldNot methods.
+//
+//For details, see the documentation for gomega.AsyncAssertion
+type AsyncAssertion interface {
+	Should(matcher GomegaMatcher, optionalDescription ...interface{}) bool
+	ShouldNot(matcher GomegaMatcher, optionalDescription ...interface{}) bool
+
+	WithContext(ctx context.Context) AsyncAssertion
+	WithTimeout(interval time.Duration) AsyncAssertion
+	WithPolling(interval time.Duration) AsyncAssertion
+	Within(timeout time.Duration) AsyncAssertion
+	ProbeEvery(interval time.Duration) AsyncAssertion
+	WithTimeline(enabled bool) AsyncAssertion
+	MustPassRepeatedly(count int) AsyncAssertion
+	WithPollingStrategy(strategy PollingStrategy) AsyncAssertion
+	WithClock(clock Clock) AsyncAssertion
+	WithFailOnPanic(enabled bool) AsyncAssertion
+}
+
+//ErrorAssertion is an Assertion that returns an error describing the failure instead of calling a fail handler.
+//The error is nil if the assertion passed.
+//
+//For details, see the documentation for gomega.Check
+type ErrorAssertion interface {
+	Should(matcher GomegaMatcher, optionalDescription ...interface{}) error
+	ShouldNot(matcher GomegaMatcher, optionalDescription ...interface{}) error
+
+	To(matcher GomegaMatcher, optionalDescription ...interface{}) error
+	ToNot(matcher GomegaMatcher, optionalDescription ...interface{}) error
+	NotTo(matcher GomegaMatcher, optionalDescription ...interface{}) error
+}
+
+//ErrorAsyncAssertion is an AsyncAssertion that returns an error describing the failure instead of calling a fail
+//handler.  The error is nil if the assertion passed.
+//
+//For details, see the documentation for gomega.NewErrorGomega
+type ErrorAsyncAssertion interface {
+	Should(matcher GomegaMatcher, optionalDescription ...interface{}) error
+	ShouldNot(matcher GomegaMatcher, optionalDescription ...interface{}) error
+
+	WithContext(ctx context.Context) ErrorAsyncAssertion
+	WithTimeout(interval time.Duration) ErrorAsyncAssertion
+	WithPolling(interval time.Duration) ErrorAsyncAssertion
+	Within(timeout time.Duration) ErrorAsyncAssertion
+	ProbeEvery(interval time.Duration) ErrorAsyncAssertion
+	WithTimeline(enabled bool) ErrorAsyncAssertion
+	MustPassRepeatedly(count int) ErrorAsyncAssertion
+	WithPollingStrategy(strategy PollingStrategy) ErrorAsyncAssertion
+	WithClock(clock Clock) ErrorAsyncAssertion
+	WithFailOnPanic(enabled bool) ErrorAsyncAssertion
+}
+
+//PollingStrategy decides how long Eventually and Consistently wait between polls.
+//
+//NextPollingInterval is given the assertion's configured polling interval and the number of polls made so far
+//(starting at 1) and returns how long to wait before the next poll.
+type PollingStrategy interface {
+	NextPollingInterval(pollingInterval time.Duration, polls int) time.Duration
+}
+
+//Assertion is returned by Ω and Expect and compares the actual value to the matcher
+//passed to the Should/ShouldNot and To/ToNot/NotTo methods.
+//
+//For details, see the documentation for gomega.Assertion
+type Assertion interface {
+	Should(matcher GomegaMatcher, optionalDescription ...interface{}) bool
+	ShouldNot(matcher GomegaMatcher, optionalDescription ...interface{}) bool
+
+	To(matcher GomegaMatcher, optionalDescription ...interface{}) bool
+	ToNot(matcher GomegaMatcher, optionalDescription ...interface{}) bool
+	NotTo(matcher GomegaMatcher, optionalDescription ...interface{}) bool
+}
+
+//ReturnValuesAssertion is returned by ExpectReturns and matches each of a function's return values against the
+//matcher in the same position.
+//
+//Its methods take the matchers variadically, so the optional description that other assertions take as trailing
+//arguments is set with WithDescription instead.
+//
+//For details, see the documentation for gomega.ExpectReturns
+type ReturnValuesAssertion interface {
+	WithDescription(optionalDescription ...interface{}) ReturnValuesAssertion
+
+	Should(matchers ...GomegaMatcher) bool
+	ShouldNot(matchers ...GomegaMatcher) bool
+
+	To(matchers ...GomegaMatcher) bool
+	ToNot(matchers ...GomegaMatcher) bool
+	NotTo(matchers ...GomegaMatcher) bool
+}
+
+//Gomega describes the essential Gomega DSL.
+//
+//For details, see the documentation for gomega.Gomega
+type Gomega interface {
+	Expect(actual interface{}, extra ...interface{}) Assertion
+	Eventually(actual interface{}, intervals ...interface{}) AsyncAssertion
+	Consistently(actual interface{}, intervals ...interface{}) AsyncAssertion
+}
+
+//Clock is the source of time used by Eventually and Consistently.
+//
+//Replacing it with a fake lets tests drive time-dependent code, and the assertions polling it, deterministically.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}